@@ -0,0 +1,113 @@
+import { describe, expect, test } from 'bun:test';
+import { buildRefinementPrompt, fitCoverLetterToLimit, refineCoverLetterLoop } from './cover-letter';
+import type { AIProvider } from '../types';
+
+function scriptedInstructions(lines: string[]): (letter: string) => Promise<string> {
+  const queue = [...lines];
+  return async () => queue.shift() ?? '';
+}
+
+describe('refineCoverLetterLoop', () => {
+  test('sends the previous draft and instruction back until accepted', async () => {
+    const prompts: string[] = [];
+    let revision = 0;
+    const provider: AIProvider = {
+      name: 'openai',
+      isAvailable: async () => true,
+      generateText: async (prompt) => {
+        prompts.push(prompt);
+        revision++;
+        return `Draft v${revision + 1}`;
+      },
+    };
+
+    const accepted = await refineCoverLetterLoop(
+      provider,
+      'Draft v1',
+      scriptedInstructions(['make it shorter', 'mention Python more', ''])
+    );
+
+    expect(accepted).toBe('Draft v3');
+    expect(prompts).toHaveLength(2);
+    expect(prompts[0]).toContain('Draft v1');
+    expect(prompts[0]).toContain('make it shorter');
+    expect(prompts[1]).toContain('Draft v2');
+    expect(prompts[1]).toContain('mention Python more');
+  });
+
+  test('returns the initial letter untouched when accepted immediately', async () => {
+    const provider: AIProvider = {
+      name: 'openai',
+      isAvailable: async () => true,
+      generateText: async () => {
+        throw new Error('should not be called');
+      },
+    };
+
+    expect(await refineCoverLetterLoop(provider, 'Original', scriptedInstructions(['   ']))).toBe(
+      'Original'
+    );
+  });
+});
+
+describe('buildRefinementPrompt', () => {
+  test('includes the prior letter and the instruction', () => {
+    const prompt = buildRefinementPrompt('Dear Acme,', 'be warmer');
+    expect(prompt).toContain('Dear Acme,');
+    expect(prompt).toContain('Revise it according to this instruction: be warmer');
+  });
+});
+
+describe('fitCoverLetterToLimit', () => {
+  const untouchable: AIProvider = {
+    name: 'openai',
+    isAvailable: async () => true,
+    generateText: async () => {
+      throw new Error('should not be called');
+    },
+  };
+
+  test('passes a letter within the limit through', async () => {
+    const result = await fitCoverLetterToLimit(untouchable, 'Short letter.', 100, { trim: true });
+    expect(result).toEqual({ letter: 'Short letter.', length: { words: 2, chars: 13 }, overLimit: false });
+  });
+
+  test('flags an over-limit letter without calling the model unless trimming', async () => {
+    const result = await fitCoverLetterToLimit(untouchable, 'A letter that runs long.', 10);
+    expect(result.overLimit).toBe(true);
+    expect(result.letter).toBe('A letter that runs long.');
+    expect(result.length.chars).toBe(24);
+  });
+
+  test('asks the model to shorten until the letter fits', async () => {
+    const prompts: string[] = [];
+    const drafts = ['Still a bit too long.', 'Fits now.'];
+    const provider: AIProvider = {
+      name: 'openai',
+      isAvailable: async () => true,
+      generateText: async (prompt) => {
+        prompts.push(prompt);
+        return drafts.shift() ?? '';
+      },
+    };
+
+    const result = await fitCoverLetterToLimit(provider, 'A letter that runs long.', 10, { trim: true });
+
+    expect(result).toEqual({ letter: 'Fits now.', length: { words: 2, chars: 9 }, overLimit: false });
+    expect(prompts).toHaveLength(2);
+    expect(prompts[0]).toContain('at most 10 characters (it is 24 now)');
+  });
+
+  test('gives up after the allowed attempts', async () => {
+    const provider: AIProvider = {
+      name: 'openai',
+      isAvailable: async () => true,
+      generateText: async () => 'Never short enough.',
+    };
+
+    const result = await fitCoverLetterToLimit(provider, 'A letter that runs long.', 10, { trim: true, attempts: 1 });
+    expect(result.overLimit).toBe(true);
+    expect(result.letter).toBe('Never short enough.');
+  });
+});
+
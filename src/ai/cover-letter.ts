@@ -1,5 +1,7 @@
 import type { AIProvider } from '../types';
 import type { Profile, JobData } from '../types';
+import { parseJsonArray, isRecord, asString } from './response';
+import { countText, type TextLength } from '../utils/text-length';
 
 const COVER_LETTER_SYSTEM_PROMPT = `You are a cover letter writer who crafts warm, human, and passionate letters. Your goal is to help the candidate stand out by showing who they truly are - not just what they can do.
 
@@ -84,6 +86,85 @@ Write a cover letter that:
 Remember: This person brings a unique perspective shaped by their background and experiences. Let that authenticity come through naturally - it's a strength, not something to hide. Write something that could only come from this specific person.`;
 }
 
+/**
+ * Revise a previously generated cover letter according to a free-form
+ * instruction ("make it shorter", "mention Python more").
+ */
+export async function refineCoverLetter(
+  provider: AIProvider,
+  previousLetter: string,
+  instruction: string
+): Promise<string> {
+  return provider.generateText(
+    buildRefinementPrompt(previousLetter, instruction),
+    COVER_LETTER_SYSTEM_PROMPT
+  );
+}
+
+export function buildRefinementPrompt(previousLetter: string, instruction: string): string {
+  return `Here is a cover letter you wrote for the candidate:
+
+---
+${previousLetter}
+---
+
+Revise it according to this instruction: ${instruction}
+
+Keep everything the instruction doesn't ask you to change. Return only the full revised cover letter, with no commentary.`;
+}
+
+/**
+ * Repeatedly ask for refinement instructions until the user accepts the
+ * current letter by returning an empty instruction.
+ */
+export async function refineCoverLetterLoop(
+  provider: AIProvider,
+  initialLetter: string,
+  nextInstruction: (currentLetter: string) => Promise<string>
+): Promise<string> {
+  let current = initialLetter;
+
+  for (;;) {
+    const instruction = (await nextInstruction(current)).trim();
+    if (!instruction) return current;
+    current = await refineCoverLetter(provider, current, instruction);
+  }
+}
+
+export interface LengthCheck {
+  letter: string;
+  length: TextLength;
+  /** Still longer than the limit (after trimming, if that was asked for) */
+  overLimit: boolean;
+}
+
+/**
+ * Check a cover letter against a form's character limit. With trim, ask the
+ * model to shorten it (up to `attempts` times) until it fits; otherwise just
+ * report whether it's over.
+ */
+export async function fitCoverLetterToLimit(
+  provider: AIProvider,
+  letter: string,
+  maxChars: number,
+  options: { trim?: boolean; attempts?: number } = {}
+): Promise<LengthCheck> {
+  const { trim = false, attempts = 2 } = options;
+  let current = letter;
+  let length = countText(current);
+
+  for (let attempt = 0; trim && length.chars > maxChars && attempt < attempts; attempt++) {
+    current = await refineCoverLetter(
+      provider,
+      current,
+      `Shorten it to at most ${maxChars} characters (it is ${length.chars} now). Keep the opening and the strongest story; cut the rest.`
+    );
+    length = countText(current);
+  }
+
+  return { letter: current, length, overLimit: length.chars > maxChars };
+}
+
 export async function answerApplicationQuestion(
   provider: AIProvider,
   profile: Profile,
@@ -192,17 +273,15 @@ ${questionsBlock}
 Return JSON array: [{"question": "...", "answer": "..."}, ...]`;
 
   const response = await provider.generateText(prompt, systemPrompt);
-  const cleaned = response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
 
   const results = new Map<string, string>();
 
   try {
-    const parsed = JSON.parse(cleaned);
-    if (Array.isArray(parsed)) {
-      for (const item of parsed) {
-        if (item.question && item.answer) {
-          results.set(String(item.question), String(item.answer));
-        }
+    for (const item of parseJsonArray(response).filter(isRecord)) {
+      const question = asString(item.question);
+      const answer = asString(item.answer);
+      if (question && answer) {
+        results.set(question, answer);
       }
     }
   } catch {
@@ -0,0 +1,78 @@
+import { appendFileSync, existsSync, mkdirSync, renameSync, statSync } from 'fs';
+import { dirname, join } from 'path';
+import { getAutoplyDir } from '../db';
+
+/** Rotate ai.log to ai.log.1 once it grows past this size */
+export const AI_LOG_MAX_BYTES = 1024 * 1024;
+
+const SECRET_ENV_VARS = ['OPENAI_API_KEY', 'ANTHROPIC_API_KEY', 'GOOGLE_API_KEY'];
+
+const SECRET_PATTERNS = [
+  /\b(Bearer|Basic)\s+[A-Za-z0-9._~+/=-]+/gi,
+  /\bsk-(?:ant-)?[A-Za-z0-9_-]{8,}/g,
+  /\bAIza[0-9A-Za-z_-]{20,}/g,
+  /("?(?:api[_-]?key|authorization|x-api-key)"?\s*[:=]\s*"?)[^"\s,}]+/gi,
+];
+
+export interface AILogEntry {
+  provider: string;
+  model: string;
+  system?: string;
+  prompt: string;
+  response?: string;
+  error?: string;
+  durationMs: number;
+}
+
+export function getAILogPath(): string {
+  return join(getAutoplyDir(), 'logs', 'ai.log');
+}
+
+export function redactSecrets(text: string): string {
+  let redacted = text;
+
+  for (const envVar of SECRET_ENV_VARS) {
+    const value = process.env[envVar];
+    if (value && value.length >= 8) {
+      redacted = redacted.split(value).join('[REDACTED]');
+    }
+  }
+
+  for (const pattern of SECRET_PATTERNS) {
+    redacted = redacted.replace(pattern, (match, prefix?: string) =>
+      typeof prefix === 'string' && /[:=]/.test(prefix) ? `${prefix}[REDACTED]` : '[REDACTED]'
+    );
+  }
+
+  return redacted;
+}
+
+function formatEntry(entry: AILogEntry): string {
+  const lines = [
+    `=== ${new Date().toISOString()} ${entry.provider}/${entry.model} (${entry.durationMs}ms) ===`,
+  ];
+  if (entry.system) lines.push('--- system ---', entry.system);
+  lines.push('--- prompt ---', entry.prompt);
+  if (entry.response !== undefined) lines.push('--- response ---', entry.response);
+  if (entry.error) lines.push('--- error ---', entry.error);
+  return redactSecrets(lines.join('\n')) + '\n\n';
+}
+
+/**
+ * Append a prompt/response pair to the AI debug log. Best-effort: logging
+ * must never break generation.
+ */
+export function writeAILog(entry: AILogEntry, logPath: string = getAILogPath()): void {
+  try {
+    const text = formatEntry(entry);
+    mkdirSync(dirname(logPath), { recursive: true });
+
+    if (existsSync(logPath) && statSync(logPath).size + text.length > AI_LOG_MAX_BYTES) {
+      renameSync(logPath, `${logPath}.1`);
+    }
+
+    appendFileSync(logPath, text);
+  } catch {
+    // Debug logging is best-effort
+  }
+}
@@ -1,4 +1,6 @@
 import type { AIProvider, Profile } from '../types';
+import { parseJsonArray, isRecord, asString } from './response';
+import { splitName, preferredFirstName } from '../utils/name';
 
 interface FormField {
   id: string;
@@ -59,17 +61,15 @@ ${fieldsDescription}
 Provide answers for each field. For select/dropdown, use EXACTLY one of the provided options.`;
 
   const response = await provider.generateText(prompt, FORM_ANALYZER_PROMPT);
-  const cleaned = response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
 
   const results = new Map<string, string>();
 
   try {
-    const parsed = JSON.parse(cleaned);
-    if (Array.isArray(parsed)) {
-      for (const item of parsed) {
-        if (item.id && item.answer) {
-          results.set(String(item.id), String(item.answer));
-        }
+    for (const item of parseJsonArray(response).filter(isRecord)) {
+      const id = asString(item.id);
+      const answer = asString(item.answer);
+      if (id && answer) {
+        results.set(id, answer);
       }
     }
   } catch {
@@ -78,11 +78,11 @@ Provide answers for each field. For select/dropdown, use EXACTLY one of the prov
       const labelLower = field.label.toLowerCase();
       
       if (labelLower.includes('preferred') && labelLower.includes('name')) {
-        results.set(field.id, profile.name.split(' ')[0]);
+        results.set(field.id, preferredFirstName(profile.name));
       } else if (labelLower.includes('location') || labelLower.includes('city')) {
         results.set(field.id, profile.location || 'Lagos, Nigeria');
       } else if (labelLower.includes('first name')) {
-        results.set(field.id, profile.name.split(' ')[0]);
+        results.set(field.id, splitName(profile.name).first);
       }
     }
   }
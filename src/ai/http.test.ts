@@ -0,0 +1,85 @@
+import { describe, expect, test, afterEach } from 'bun:test';
+import type { Server } from 'bun';
+import { createAIFetch, resolveProxy } from './http';
+
+let server: Server | undefined;
+
+afterEach(() => {
+  server?.stop(true);
+  server = undefined;
+});
+
+function capture(): { fetch: (input: string | URL | Request, init?: RequestInit) => Promise<Response>; init: () => Record<string, unknown> } {
+  let seen: Record<string, unknown> = {};
+  return {
+    fetch: async (_input, init) => {
+      seen = { ...init } as Record<string, unknown>;
+      return new Response('ok');
+    },
+    init: () => seen,
+  };
+}
+
+describe('resolveProxy', () => {
+  const env = { HTTPS_PROXY: 'http://proxy.corp:8080', http_proxy: 'http://plain.corp:3128', NO_PROXY: 'localhost,.internal' };
+
+  test('picks the proxy for the URL scheme', () => {
+    expect(resolveProxy('https://api.openai.com/v1', undefined, env)).toBe('http://proxy.corp:8080');
+    expect(resolveProxy('http://gpu-box:11434/v1', undefined, env)).toBe('http://plain.corp:3128');
+  });
+
+  test('skips hosts in NO_PROXY, even with ai.proxy set', () => {
+    expect(resolveProxy('http://localhost:11434', undefined, env)).toBeUndefined();
+    expect(resolveProxy('https://llm.internal/v1', 'http://other:1', env)).toBeUndefined();
+  });
+
+  test('prefers the configured proxy over the environment', () => {
+    expect(resolveProxy('https://api.openai.com', 'http://other:1', env)).toBe('http://other:1');
+    expect(resolveProxy('https://api.openai.com', undefined, {})).toBeUndefined();
+  });
+});
+
+describe('createAIFetch', () => {
+  test('passes the proxy and TLS settings to fetch', async () => {
+    const base = capture();
+    const aiFetch = createAIFetch({ insecureSkipVerify: true }, base.fetch, { HTTPS_PROXY: 'http://proxy.corp:8080' });
+
+    await aiFetch('https://gateway.example/v1/chat', { method: 'POST' });
+
+    expect(base.init()).toMatchObject({
+      method: 'POST',
+      proxy: 'http://proxy.corp:8080',
+      tls: { rejectUnauthorized: false },
+    });
+  });
+
+  test('leaves TLS verification and proxies alone by default', async () => {
+    const base = capture();
+    await createAIFetch({}, base.fetch, {})('https://api.openai.com/v1');
+
+    expect(base.init().proxy).toBeUndefined();
+    expect(base.init().tls).toBeUndefined();
+  });
+
+  test('gives up on a request slower than ai.timeout', async () => {
+    server = Bun.serve({
+      port: 0,
+      async fetch() {
+        await Bun.sleep(500);
+        return new Response('late');
+      },
+    });
+
+    const aiFetch = createAIFetch({ timeout: 50 }, fetch, {});
+    await expect(aiFetch(`http://localhost:${server.port}/v1/chat`)).rejects.toThrow('AI request timed out');
+  });
+
+  test('still honours the caller abort signal', async () => {
+    const controller = new AbortController();
+    const base = capture();
+    await createAIFetch({}, base.fetch, {})('https://api.openai.com/v1', { signal: controller.signal });
+
+    controller.abort();
+    expect((base.init().signal as AbortSignal).aborted).toBe(true);
+  });
+});
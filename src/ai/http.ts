@@ -0,0 +1,75 @@
+import type { AIConfig } from '../types';
+
+/** Default limit for a single AI request; local models on slow hardware can take a while */
+export const DEFAULT_AI_TIMEOUT_MS = 120_000;
+
+type Env = Record<string, string | undefined>;
+type FetchFn = (input: string | URL | Request, init?: RequestInit) => Promise<Response>;
+
+/** Bun's fetch extensions for proxies and TLS */
+interface BunRequestInit extends RequestInit {
+  proxy?: string;
+  tls?: { rejectUnauthorized?: boolean };
+}
+
+function hostMatchesNoProxy(host: string, noProxy: string): boolean {
+  return noProxy
+    .split(',')
+    .map((entry) => entry.trim().toLowerCase().replace(/^\*?\./, ''))
+    .filter(Boolean)
+    .some((entry) => entry === '*' || host === entry || host.endsWith(`.${entry}`));
+}
+
+/**
+ * Proxy for a request: ai.proxy if set, otherwise HTTPS_PROXY / HTTP_PROXY
+ * (either case) for the URL's scheme, skipping hosts listed in NO_PROXY.
+ */
+export function resolveProxy(url: string, configured: string | undefined, env: Env = process.env): string | undefined {
+  const { protocol, hostname } = new URL(url);
+  const noProxy = env.NO_PROXY ?? env.no_proxy;
+  if (noProxy && hostMatchesNoProxy(hostname.toLowerCase(), noProxy)) return undefined;
+  if (configured) return configured;
+
+  return protocol === 'https:'
+    ? env.HTTPS_PROXY ?? env.https_proxy ?? env.HTTP_PROXY ?? env.http_proxy
+    : env.HTTP_PROXY ?? env.http_proxy;
+}
+
+function requestUrl(input: string | URL | Request): string {
+  if (typeof input === 'string') return input;
+  return input instanceof URL ? input.href : input.url;
+}
+
+/**
+ * fetch used by every AI provider: gives up after ai.timeout ms, goes through
+ * the configured or environment proxy, and with ai.insecureSkipVerify accepts
+ * self-signed certificates (for local gateways).
+ */
+export function createAIFetch(
+  config: Pick<AIConfig, 'timeout' | 'proxy' | 'insecureSkipVerify'>,
+  baseFetch: FetchFn = fetch,
+  env: Env = process.env
+): FetchFn {
+  const timeoutMs = config.timeout ?? DEFAULT_AI_TIMEOUT_MS;
+
+  return async (input, init = {}) => {
+    const timeout = AbortSignal.timeout(timeoutMs);
+    const request: BunRequestInit = {
+      ...init,
+      signal: init.signal ? AbortSignal.any([init.signal, timeout]) : timeout,
+    };
+
+    const proxy = resolveProxy(requestUrl(input), config.proxy, env);
+    if (proxy) request.proxy = proxy;
+    if (config.insecureSkipVerify) request.tls = { rejectUnauthorized: false };
+
+    try {
+      return await baseFetch(input, request);
+    } catch (error) {
+      if (timeout.aborted) {
+        throw new Error(`AI request timed out after ${Math.round(timeoutMs / 1000)}s (raise ai.timeout if your model is slow)`);
+      }
+      throw error;
+    }
+  };
+}
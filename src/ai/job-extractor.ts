@@ -1,4 +1,5 @@
 import type { AIProvider, JobData } from '../types';
+import { parseJsonObject, asString, asStringArray } from './response';
 
 const EXTRACTION_SYSTEM_PROMPT = `You extract job posting data from raw HTML content. Return ONLY valid JSON, no markdown.
 
@@ -48,19 +49,27 @@ ${truncated}`;
 
   const response = await provider.generateText(prompt, EXTRACTION_SYSTEM_PROMPT);
 
-  const cleaned = response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
-  const parsed: ExtractedJobData = JSON.parse(cleaned);
+  const parsed = parseJsonObject(response);
 
   const result: Partial<JobData> = {};
 
-  if (parsed.title) result.title = parsed.title;
-  if (parsed.company) result.company = parsed.company;
-  if (parsed.location) result.location = parsed.location;
-  if (parsed.description) result.description = parsed.description;
-  if (parsed.requirements) result.requirements = parsed.requirements;
-  if (parsed.qualifications) result.qualifications = parsed.qualifications;
-  if (parsed.salary) result.salary = parsed.salary;
-  if (parsed.job_type) result.job_type = parsed.job_type;
+  const title = asString(parsed.title);
+  const company = asString(parsed.company);
+  const location = asString(parsed.location);
+  const description = asString(parsed.description);
+  const requirements = asStringArray(parsed.requirements);
+  const qualifications = asStringArray(parsed.qualifications);
+  const salary = asString(parsed.salary);
+  const jobType = asString(parsed.job_type);
+
+  if (title) result.title = title;
+  if (company) result.company = company;
+  if (location) result.location = location;
+  if (description) result.description = description;
+  if (requirements.length > 0) result.requirements = requirements;
+  if (qualifications.length > 0) result.qualifications = qualifications;
+  if (salary) result.salary = salary;
+  if (jobType) result.job_type = jobType;
 
   return result;
 }
@@ -0,0 +1,265 @@
+import { describe, expect, test } from 'bun:test';
+import { evaluateJobFit, explainFit, parseOverallScore, recentSkillsInJob, weightedFitScore, FIT_WEIGHTS } from './job-matcher';
+import type { AIProvider, JobData, Profile } from '../types';
+
+function replyWith(text: string): AIProvider {
+  return {
+    name: 'openai',
+    generateText: async () => text,
+    isAvailable: async () => true,
+  };
+}
+
+const profile: Profile = {
+  name: 'Ada Lovelace',
+  email: 'ada@example.com',
+  skills: ['TypeScript', 'Go'],
+  experience: [],
+  education: [],
+};
+
+const jobData: JobData = {
+  url: 'https://example.com/job',
+  platform: 'generic',
+  title: 'Backend Engineer',
+  company: 'Acme',
+  description: 'Build APIs',
+  requirements: ['Go', 'Kubernetes'],
+  qualifications: [],
+  form_fields: [],
+  custom_questions: [],
+};
+
+describe('weightedFitScore', () => {
+  test('weights sum to one', () => {
+    const total = Object.values(FIT_WEIGHTS).reduce((sum, w) => sum + w, 0);
+    expect(total).toBeCloseTo(1);
+  });
+
+  test('combines factors by weight', () => {
+    expect(weightedFitScore({ skills: 80, experience: 60, location: 100, title: 50 })).toBe(70);
+  });
+
+  test('leaves missing factors out of the denominator', () => {
+    expect(weightedFitScore({ skills: 90 })).toBe(90);
+    expect(weightedFitScore({ skills: 100, location: 0 })).toBe(80);
+    expect(weightedFitScore({})).toBe(0);
+  });
+});
+
+describe('parseOverallScore', () => {
+  test('keeps a genuine zero', () => {
+    expect(parseOverallScore(0)).toBe(0);
+    expect(parseOverallScore('0')).toBe(0);
+  });
+
+  test('reads fractions as percentages', () => {
+    expect(parseOverallScore(0.85)).toBe(85);
+    expect(parseOverallScore(1)).toBe(1);
+  });
+
+  test('clamps and falls back to the neutral score', () => {
+    expect(parseOverallScore(140)).toBe(100);
+    expect(parseOverallScore(-3)).toBe(0);
+    expect(parseOverallScore(undefined, 40)).toBe(40);
+    expect(parseOverallScore('high')).toBe(50);
+    expect(parseOverallScore(true)).toBe(50);
+  });
+});
+
+describe('fit score bounds', () => {
+  // Simple LCG so failures are reproducible
+  function seeded(seed: number): () => number {
+    let state = seed;
+    return () => {
+      state = (state * 1664525 + 1013904223) % 4294967296;
+      return state / 4294967296;
+    };
+  }
+
+  function randomValue(next: () => number): unknown {
+    const pick = next();
+    if (pick < 0.1) return undefined;
+    if (pick < 0.2) return null;
+    if (pick < 0.3) return 'n/a';
+    if (pick < 0.4) return next();
+    return Math.round((next() - 0.3) * 400);
+  }
+
+  test('stays within 0-100 for arbitrary model output', async () => {
+    const next = seeded(42);
+    for (let i = 0; i < 200; i++) {
+      const reply = {
+        score: randomValue(next),
+        breakdown: next() < 0.7
+          ? {
+              skills: randomValue(next),
+              experience: randomValue(next),
+              location: randomValue(next),
+              title: randomValue(next),
+            }
+          : undefined,
+      };
+      const result = await evaluateJobFit(replyWith(JSON.stringify(reply)), profile, jobData);
+
+      expect(result.score).toBeGreaterThanOrEqual(0);
+      expect(result.score).toBeLessThanOrEqual(100);
+      expect(Number.isInteger(result.score)).toBe(true);
+    }
+  });
+});
+
+describe('evaluateJobFit breakdown', () => {
+  test('parses sub-scores and derives the total from them', async () => {
+    const result = await evaluateJobFit(
+      replyWith(JSON.stringify({
+        score: 95,
+        breakdown: { skills: 80, experience: 60, location: 100, title: 50 },
+        strongMatches: ['Go'],
+        missingSkills: ['Kubernetes'],
+      })),
+      profile,
+      jobData
+    );
+
+    expect(result.breakdown).toEqual({ skills: 80, experience: 60, location: 100, title: 50 });
+    expect(result.score).toBe(weightedFitScore(result.breakdown!));
+    expect(result.score).toBe(70);
+  });
+
+  test('clamps out-of-range sub-scores', async () => {
+    const result = await evaluateJobFit(
+      replyWith('{"breakdown": {"skills": 140, "experience": -5, "location": "90", "title": 70}}'),
+      profile,
+      jobData
+    );
+
+    expect(result.breakdown).toEqual({ skills: 100, experience: 0, location: 90, title: 70 });
+  });
+
+  test('scores a partial breakdown over the factors present', async () => {
+    const result = await evaluateJobFit(
+      replyWith('{"score": 64, "breakdown": {"skills": 80, "title": 50}}'),
+      profile,
+      jobData
+    );
+
+    expect(result.breakdown).toEqual({ skills: 80, title: 50 });
+    expect(result.score).toBe(70);
+  });
+
+  test('keeps the model score when no factor is usable', async () => {
+    const result = await evaluateJobFit(
+      replyWith('{"score": 64, "breakdown": {"skills": "n/a"}}'),
+      profile,
+      jobData
+    );
+
+    expect(result.breakdown).toBeUndefined();
+    expect(result.score).toBe(64);
+  });
+
+  test('falls back to the configured neutral score', async () => {
+    const result = await evaluateJobFit(replyWith('not json'), profile, jobData, { neutralScore: 40 });
+
+    expect(result.score).toBe(40);
+  });
+});
+
+describe('explainFit', () => {
+  test('lists each factor, the total, and matched/missing skills', () => {
+    const lines = explainFit({
+      score: 70,
+      reasoning: 'Solid backend match.',
+      strongMatches: ['Go'],
+      missingSkills: ['Kubernetes'],
+      recommendation: 'good',
+      breakdown: { skills: 80, experience: 60, location: 100, title: 50 },
+    });
+
+    expect(lines[0]).toContain('Skills');
+    expect(lines[0]).toContain('80%');
+    expect(lines.some((l) => l.startsWith('Total') && l.includes('70%'))).toBe(true);
+    expect(lines).toContain('Matched: Go');
+    expect(lines).toContain('Missing: Kubernetes');
+  });
+
+  test('omits factor lines when there is no breakdown', () => {
+    const lines = explainFit({
+      score: 50,
+      reasoning: '',
+      strongMatches: [],
+      missingSkills: ['Rust'],
+      recommendation: 'stretch',
+    });
+
+    expect(lines).toEqual(['Missing: Rust']);
+  });
+
+  test('marks factors the model did not score', () => {
+    const lines = explainFit({
+      score: 80,
+      reasoning: '',
+      strongMatches: [],
+      missingSkills: [],
+      recommendation: 'strong',
+      breakdown: { skills: 80 },
+    });
+
+    expect(lines[1]).toContain('n/a');
+  });
+});
+
+describe('evaluateJobFit seniority', () => {
+  const reply = replyWith(JSON.stringify({
+    breakdown: { skills: 80, experience: 60, location: 100, title: 50 },
+  }));
+
+  test('deducts a penalty when a junior candidate targets a senior role', async () => {
+    const oneYearAgo = new Date();
+    oneYearAgo.setFullYear(oneYearAgo.getFullYear() - 1);
+    const junior: Profile = {
+      ...profile,
+      experience: [
+        { company: 'Acme', title: 'Engineer', start_date: oneYearAgo.toISOString().slice(0, 7), highlights: [] },
+      ],
+    };
+
+    const result = await evaluateJobFit(reply, junior, { ...jobData, title: 'Senior Backend Engineer' });
+
+    expect(result.seniorityPenalty).toBe(20);
+    expect(result.score).toBe(50);
+    expect(explainFit(result)).toContain('Seniority   -20');
+  });
+
+  test('does not penalize when the profile lists no roles', async () => {
+    const result = await evaluateJobFit(reply, profile, { ...jobData, title: 'Senior Backend Engineer' });
+
+    expect(result.seniorityPenalty).toBeUndefined();
+    expect(result.score).toBe(70);
+  });
+});
+
+describe('recent experience skills', () => {
+  const tagged: Profile = {
+    ...profile,
+    experience: [
+      { company: 'Acme', title: 'Engineer', start_date: '2015', end_date: '2016', highlights: [], skills: ['Perl'] },
+      { company: 'Initech', title: 'Engineer', start_date: '2022', highlights: [], skills: ['Go', 'Kubernetes', 'React'] },
+    ],
+  };
+
+  test('picks recent-role skills the job mentions', () => {
+    expect(recentSkillsInJob(tagged, jobData)).toEqual(['Go', 'Kubernetes']);
+  });
+
+  test('adds a capped bonus to the score and explains it', async () => {
+    const reply = replyWith(JSON.stringify({ breakdown: { skills: 80, experience: 60, location: 100, title: 50 } }));
+    const result = await evaluateJobFit(reply, tagged, jobData);
+
+    expect(result.recentSkillBonus).toBe(4);
+    expect(result.score).toBe(74);
+    expect(explainFit(result)).toContain('Recent use   +4  (recent: Go, Kubernetes)');
+  });
+});
+
@@ -1,4 +1,21 @@
 import type { AIProvider, Profile, JobData } from '../types';
+import { parseJsonObject, asString, asStringArray, isRecord } from './response';
+import { normalizeLocation, formatLocation, isRemoteJob } from '../utils/location';
+import {
+  totalExperienceYears,
+  seniorityFromYears,
+  classifyJobSeniority,
+  seniorityPenalty,
+  recentExperienceSkills,
+} from '../utils/experience';
+import { mentions } from '../utils/mentions';
+
+export interface FitBreakdown {
+  skills: number;
+  experience: number;
+  location: number;
+  title: number;
+}
 
 export interface JobFitResult {
   score: number;
@@ -6,6 +23,121 @@ export interface JobFitResult {
   strongMatches: string[];
   missingSkills: string[];
   recommendation: 'strong' | 'good' | 'stretch' | 'skip';
+  /** Per-factor sub-scores (0-100) for the factors the model provided */
+  breakdown?: Partial<FitBreakdown>;
+  /** Points deducted because the role's seniority is far from the candidate's tenure */
+  seniorityPenalty?: number;
+  /** Points added because the job asks for skills used in recent roles */
+  recentSkillBonus?: number;
+  /** The recent-role skills the job mentions */
+  recentSkills?: string[];
+}
+
+/** How much each factor contributes to the overall fit score */
+export const FIT_WEIGHTS: FitBreakdown = {
+  skills: 0.4,
+  experience: 0.3,
+  title: 0.2,
+  location: 0.1,
+};
+
+const FIT_FACTORS = Object.keys(FIT_WEIGHTS) as Array<keyof FitBreakdown>;
+
+/** Points per recent-role skill the job mentions, and the most they can add */
+export const RECENT_SKILL_POINTS = 2;
+export const MAX_RECENT_SKILL_BONUS = 6;
+
+/** Skills tagged on recent roles that the job posting mentions anywhere. */
+export function recentSkillsInJob(profile: Profile, jobData: JobData, now: Date = new Date()): string[] {
+  const jobText = [jobData.title, jobData.description, ...jobData.requirements, ...jobData.qualifications].join('\n');
+  return recentExperienceSkills(profile.experience, now).filter((skill) => mentions(jobText, skill));
+}
+
+/** Score used when the model's answer can't be read */
+export const DEFAULT_NEUTRAL_FIT_SCORE = 50;
+
+export interface EvaluateFitOptions {
+  /** Score to fall back on when the response is unusable (default 50) */
+  neutralScore?: number;
+}
+
+/**
+ * Weighted average of the factors present. Missing factors are left out of
+ * both sides, so the result stays on the 0-100 scale instead of being
+ * dragged down (or inflated) by factors the model didn't score.
+ */
+export function weightedFitScore(breakdown: Partial<FitBreakdown>): number {
+  let total = 0;
+  let weight = 0;
+  for (const factor of FIT_FACTORS) {
+    const score = breakdown[factor];
+    if (score === undefined) continue;
+    total += score * FIT_WEIGHTS[factor];
+    weight += FIT_WEIGHTS[factor];
+  }
+  return weight > 0 ? Math.round(total / weight) : 0;
+}
+
+function clampScore(value: unknown): number | undefined {
+  const n = Number(value);
+  if (value === null || value === '' || !Number.isFinite(n)) return undefined;
+  return Math.min(100, Math.max(0, Math.round(n)));
+}
+
+/**
+ * The model's overall score on the 0-100 scale. A real 0 is kept (it used
+ * to fall through to the neutral score), and fractions like 0.85 are read
+ * as 85%.
+ */
+export function parseOverallScore(value: unknown, neutralScore: number = DEFAULT_NEUTRAL_FIT_SCORE): number {
+  const n = Number(value);
+  if (value === null || value === '' || typeof value === 'boolean' || !Number.isFinite(n)) {
+    return clampScore(neutralScore) ?? DEFAULT_NEUTRAL_FIT_SCORE;
+  }
+  return clampScore(n > 0 && n < 1 ? n * 100 : n)!;
+}
+
+function parseBreakdown(value: unknown): Partial<FitBreakdown> | undefined {
+  if (!isRecord(value)) return undefined;
+
+  const breakdown: Partial<FitBreakdown> = {};
+  for (const factor of FIT_FACTORS) {
+    const score = clampScore(value[factor]);
+    if (score !== undefined) breakdown[factor] = score;
+  }
+  return Object.keys(breakdown).length > 0 ? breakdown : undefined;
+}
+
+/**
+ * Human-readable lines explaining how a fit score was reached.
+ */
+export function explainFit(result: JobFitResult): string[] {
+  const lines: string[] = [];
+
+  if (result.breakdown) {
+    for (const factor of FIT_FACTORS) {
+      const label = factor.charAt(0).toUpperCase() + factor.slice(1);
+      const weight = Math.round(FIT_WEIGHTS[factor] * 100);
+      const score = result.breakdown[factor];
+      lines.push(score === undefined
+        ? `${label.padEnd(11)}  n/a  (not scored)`
+        : `${label.padEnd(11)} ${String(score).padStart(3)}%  (weight ${weight}%)`);
+    }
+    if (result.seniorityPenalty) {
+      lines.push(`${'Seniority'.padEnd(11)} ${String(-result.seniorityPenalty).padStart(3)}`);
+    }
+    if (result.recentSkillBonus) {
+      const skills = result.recentSkills?.length ? `  (recent: ${result.recentSkills.join(', ')})` : '';
+      lines.push(`${'Recent use'.padEnd(11)} ${`+${result.recentSkillBonus}`.padStart(3)}${skills}`);
+    }
+    lines.push(`${'Total'.padEnd(11)} ${String(result.score).padStart(3)}%`);
+  }
+
+  if (result.strongMatches.length > 0) lines.push(`Matched: ${result.strongMatches.join(', ')}`);
+  if (result.missingSkills.length > 0) lines.push(`Missing: ${result.missingSkills.join(', ')}`);
+  if (result.reasoning) lines.push(result.reasoning);
+
+  return lines;
 }
 
 const FIT_SYSTEM_PROMPT = `You evaluate how well a candidate matches a job posting. Return ONLY valid JSON, no markdown fences.
@@ -13,6 +145,12 @@ const FIT_SYSTEM_PROMPT = `You evaluate how well a candidate matches a job posti
 Schema:
 {
   "score": 0-100,
+  "breakdown": {
+    "skills": 0-100,
+    "experience": 0-100,
+    "location": 0-100,
+    "title": 0-100
+  },
   "reasoning": "1-2 sentence summary",
   "strongMatches": ["skill or qualification that matches well"],
   "missingSkills": ["required skill the candidate lacks"],
@@ -25,51 +163,81 @@ Scoring guide:
 - 40-59: Stretch, significant gaps but transferable skills exist
 - 0-39: Skip, fundamental mismatch
 
+Breakdown factors:
+- skills: overlap between the candidate's skills and the required skills
+- experience: years and seniority relative to what the role asks for
+- location: whether the candidate can work where the role is based (remote counts as a match)
+- title: how closely the candidate's recent titles match this role
+
 Be honest and practical. A senior role for a junior candidate is a skip. Missing a "nice-to-have" shouldn't tank the score.`;
 
+function describeJobLocation(jobData: JobData): string {
+  const place = formatLocation(normalizeLocation(jobData.location));
+  if (isRemoteJob(jobData) && !place.includes('Remote')) {
+    return place ? `${place} (Remote)` : 'Remote';
+  }
+  return place || 'Not specified';
+}
+
 export async function evaluateJobFit(
   provider: AIProvider,
   profile: Profile,
-  jobData: JobData
+  jobData: JobData,
+  options: EvaluateFitOptions = {}
 ): Promise<JobFitResult> {
+  const neutralScore = options.neutralScore ?? DEFAULT_NEUTRAL_FIT_SCORE;
+  const years = totalExperienceYears(profile.experience);
+  const candidateLevel = seniorityFromYears(years);
+  const jobLevel = classifyJobSeniority(jobData.title);
+  // Without any listed roles we can't judge tenure, so don't penalize
+  const penalty = profile.experience.length > 0 ? seniorityPenalty(candidateLevel, jobLevel) : 0;
+  const recentSkills = recentSkillsInJob(profile, jobData);
+  const bonus = Math.min(MAX_RECENT_SKILL_BONUS, recentSkills.length * RECENT_SKILL_POINTS);
+
   const prompt = `Evaluate this candidate's fit for the role.
 
 ## Candidate
-Skills: ${profile.skills.join(', ')}
-Experience: ${profile.experience.slice(0, 3).map(e => `${e.title} at ${e.company} (${e.start_date} - ${e.end_date ?? 'Present'})`).join('; ')}
+Location: ${profile.location ? formatLocation(normalizeLocation(profile.location)) : 'Not provided'}${profile.preferences?.remote_only ? ' (remote only)' : ''}
+${profile.preferences?.preferred_locations?.length ? `Preferred locations: ${profile.preferences.preferred_locations.join(', ')}\n` : ''}Skills: ${profile.skills.join(', ')}
+Total experience: ${years} years (${candidateLevel} level)
+Experience: ${profile.experience.slice(0, 3).map(e => `${e.title} at ${e.company} (${e.start_date} - ${e.end_date ?? 'Present'})${e.skills?.length ? ` [used: ${e.skills.join(', ')}]` : ''}`).join('; ')}
 Education: ${profile.education.map(e => `${e.degree}${e.field ? ' in ' + e.field : ''} - ${e.institution}`).join('; ')}
 
 ## Job
 Title: ${jobData.title}
 Company: ${jobData.company}
+Location: ${describeJobLocation(jobData)}
 Description: ${jobData.description.slice(0, 2000)}
 Requirements: ${jobData.requirements.slice(0, 10).join('; ')}
 Qualifications: ${jobData.qualifications.slice(0, 10).join('; ')}`;
 
   const response = await provider.generateText(prompt, FIT_SYSTEM_PROMPT);
-  const cleaned = response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
 
   let parsed: Record<string, unknown>;
   try {
-    parsed = JSON.parse(cleaned);
+    parsed = parseJsonObject(response);
   } catch {
-    const jsonMatch = cleaned.match(/\{[\s\S]*\}/);
-    if (!jsonMatch) {
-      return { score: 50, reasoning: 'Could not evaluate fit', strongMatches: [], missingSkills: [], recommendation: 'good' };
-    }
-    parsed = JSON.parse(jsonMatch[0]);
+    return { score: neutralScore, reasoning: 'Could not evaluate fit', strongMatches: [], missingSkills: [], recommendation: 'good' };
   }
 
-  const score = Math.min(100, Math.max(0, Number(parsed.score) || 50));
+  // When the model gives a breakdown, derive the total from it so the
+  // explanation always adds up to the score we show.
+  const breakdown = parseBreakdown(parsed.breakdown);
+  const baseScore = breakdown ? weightedFitScore(breakdown) : parseOverallScore(parsed.score, neutralScore);
+  const score = Math.min(100, Math.max(0, baseScore - penalty + bonus));
   const recommendation = (['strong', 'good', 'stretch', 'skip'].includes(String(parsed.recommendation))
     ? String(parsed.recommendation)
     : score >= 80 ? 'strong' : score >= 60 ? 'good' : score >= 40 ? 'stretch' : 'skip') as JobFitResult['recommendation'];
 
   return {
     score,
-    reasoning: String(parsed.reasoning || ''),
-    strongMatches: Array.isArray(parsed.strongMatches) ? parsed.strongMatches.map(String) : [],
-    missingSkills: Array.isArray(parsed.missingSkills) ? parsed.missingSkills.map(String) : [],
+    reasoning: asString(parsed.reasoning) ?? '',
+    strongMatches: asStringArray(parsed.strongMatches),
+    missingSkills: asStringArray(parsed.missingSkills),
     recommendation,
+    breakdown,
+    seniorityPenalty: penalty || undefined,
+    recentSkillBonus: bonus || undefined,
+    recentSkills: recentSkills.length > 0 ? recentSkills : undefined,
   };
 }
@@ -0,0 +1,96 @@
+import { describe, expect, test, afterEach } from 'bun:test';
+import type { Server } from 'bun';
+import {
+  clearOllamaModelCache,
+  ensureOllamaModel,
+  isModelInstalled,
+  listOllamaModels,
+} from './ollama';
+
+let server: Server | undefined;
+
+function serveTags(models: string[]): { url: string; hits: () => number } {
+  let hits = 0;
+  server = Bun.serve({
+    port: 0,
+    fetch(req) {
+      if (new URL(req.url).pathname === '/api/tags') {
+        hits++;
+        return Response.json({ models: models.map((name) => ({ name })) });
+      }
+      return new Response('not found', { status: 404 });
+    },
+  });
+  return { url: `http://localhost:${server.port}`, hits: () => hits };
+}
+
+afterEach(() => {
+  server?.stop(true);
+  server = undefined;
+  clearOllamaModelCache();
+});
+
+describe('isModelInstalled', () => {
+  test('matches an untagged name against its :latest tag', () => {
+    expect(isModelInstalled('llama3.2', ['llama3.2:latest'])).toBe(true);
+  });
+
+  test('requires an exact match for explicitly tagged names', () => {
+    expect(isModelInstalled('llama3.2:1b', ['llama3.2:latest'])).toBe(false);
+    expect(isModelInstalled('llama3.2:1b', ['llama3.2:1b'])).toBe(true);
+  });
+
+  test('does not treat other tags as :latest', () => {
+    expect(isModelInstalled('llama3.2', ['llama3.2:1b'])).toBe(false);
+  });
+});
+
+describe('ensureOllamaModel', () => {
+  test('passes when the model is pulled', async () => {
+    const { url } = serveTags(['llama3.2:latest']);
+    await expect(ensureOllamaModel('llama3.2', { baseUrl: url })).resolves.toBeUndefined();
+  });
+
+  test('suggests ollama pull and lists available models when missing', async () => {
+    const { url } = serveTags(['mistral:latest', 'qwen2.5:7b']);
+
+    await expect(ensureOllamaModel('llama3.2', { baseUrl: url })).rejects.toThrow('Run: ollama pull llama3.2');
+    await expect(ensureOllamaModel('llama3.2', { baseUrl: url })).rejects.toThrow(
+      'Available models: mistral:latest, qwen2.5:7b'
+    );
+  });
+
+  test('accepts an OpenAI-style /v1 base URL', async () => {
+    const { url } = serveTags(['llama3.2:latest']);
+    await expect(ensureOllamaModel('llama3.2', { baseUrl: `${url}/v1` })).resolves.toBeUndefined();
+  });
+
+  test('reports an unreachable server', async () => {
+    await expect(ensureOllamaModel('llama3.2', { baseUrl: 'http://127.0.0.1:1' })).rejects.toThrow(
+      'ollama serve'
+    );
+  });
+});
+
+describe('listOllamaModels', () => {
+  test('uses the configured AI timeout', async () => {
+    server = Bun.serve({
+      port: 0,
+      async fetch() {
+        await Bun.sleep(1000);
+        return Response.json({ models: [] });
+      },
+    });
+
+    await expect(listOllamaModels({ baseUrl: `http://localhost:${server.port}`, timeout: 50 })).rejects.toThrow('timed out');
+  });
+
+  test('caches the tag list per server', async () => {
+    const { url, hits } = serveTags(['llama3.2:latest']);
+
+    await listOllamaModels({ baseUrl: url });
+    await listOllamaModels({ baseUrl: url });
+
+    expect(hits()).toBe(1);
+  });
+});
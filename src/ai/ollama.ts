@@ -0,0 +1,75 @@
+/**
+ * Ollama pre-flight checks. When the configured model hasn't been pulled,
+ * Ollama's OpenAI-compatible endpoint only returns a bare 404, so we look at
+ * the installed tags first and turn that into an actionable error.
+ */
+
+import type { AIConfig } from '../types';
+import { createAIFetch } from './http';
+
+const DEFAULT_OLLAMA_URL = 'http://localhost:11434';
+
+// Installed model names per Ollama server, cached for the lifetime of the command
+const tagCache = new Map<string, string[]>();
+
+/** Where the server is, plus the same timeout/proxy/TLS settings as model requests */
+export type OllamaConnection = Pick<AIConfig, 'baseUrl' | 'timeout' | 'proxy' | 'insecureSkipVerify'>;
+
+interface OllamaTagsResponse {
+  models?: Array<{ name?: unknown }>;
+}
+
+function ollamaRoot(baseUrl?: string): string {
+  return (baseUrl ?? DEFAULT_OLLAMA_URL).replace(/\/+$/, '').replace(/\/v1$/, '');
+}
+
+export async function listOllamaModels(connection: OllamaConnection = {}): Promise<string[]> {
+  const root = ollamaRoot(connection.baseUrl);
+  const cached = tagCache.get(root);
+  if (cached) return cached;
+
+  const response = await createAIFetch(connection)(`${root}/api/tags`);
+  if (!response.ok) {
+    throw new Error(`Ollama returned HTTP ${response.status} from ${root}/api/tags`);
+  }
+
+  const body = (await response.json()) as OllamaTagsResponse;
+  const models = (body.models ?? [])
+    .map((m) => m.name)
+    .filter((name): name is string => typeof name === 'string');
+
+  tagCache.set(root, models);
+  return models;
+}
+
+export function clearOllamaModelCache(): void {
+  tagCache.clear();
+}
+
+/**
+ * Ollama resolves an untagged model name to its ":latest" tag.
+ */
+export function isModelInstalled(model: string, installed: string[]): boolean {
+  return installed.some(
+    (name) => name === model || (!model.includes(':') && name === `${model}:latest`)
+  );
+}
+
+export async function ensureOllamaModel(model: string, connection: OllamaConnection = {}): Promise<void> {
+  const root = ollamaRoot(connection.baseUrl);
+
+  let installed: string[];
+  try {
+    installed = await listOllamaModels(connection);
+  } catch (error) {
+    const msg = error instanceof Error ? error.message : 'Unknown error';
+    throw new Error(`Cannot reach Ollama at ${root} (${msg}). Start it with: ollama serve`);
+  }
+
+  if (isModelInstalled(model, installed)) return;
+
+  const available = installed.length > 0
+    ? `Available models: ${installed.join(', ')}`
+    : 'No models are installed yet.';
+  throw new Error(`Ollama model "${model}" is not installed. Run: ollama pull ${model}\n${available}`);
+}
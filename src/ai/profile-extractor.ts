@@ -1,4 +1,5 @@
 import type { AIProvider, Profile, Experience, Education, Preferences } from '../types';
+import { parseJsonObject, isRecord, asString, asStringArray } from './response';
 
 const EXTRACTION_SYSTEM_PROMPT = `You extract structured profile data from resumes. Return ONLY valid JSON, no markdown fences or extra text.
 
@@ -20,7 +21,8 @@ The JSON must match this exact schema:
       "start_date": "string",
       "end_date": "string or null",
       "description": "string or null",
-      "highlights": ["string"]
+      "highlights": ["string"],
+      "skills": ["string"]
     }
   ],
   "education": [
@@ -38,6 +40,7 @@ The JSON must match this exact schema:
 Rules:
 - Extract ALL experience entries, not just the most recent
 - For highlights, extract bullet points / achievements from each role
+- For each role's skills, list the technologies and tools the resume says were used in that role
 - Skills should be individual technologies, tools, and competencies — not sentences
 - Dates should be in a readable format like "Jan 2022" or "2022"
 - If a field isn't present in the resume, use null
@@ -50,29 +53,22 @@ export async function extractProfileFromResume(
   const prompt = `Extract structured profile data from this resume:\n\n${resumeText}`;
 
   const response = await provider.generateText(prompt, EXTRACTION_SYSTEM_PROMPT);
-  const cleaned = response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
-
   let parsed: Record<string, unknown>;
   try {
-    parsed = JSON.parse(cleaned);
+    parsed = parseJsonObject(response);
   } catch {
-    // Try to find JSON in the response
-    const jsonMatch = cleaned.match(/\{[\s\S]*\}/);
-    if (!jsonMatch) {
-      throw new Error('AI returned invalid JSON. Try again or use manual profile setup.');
-    }
-    parsed = JSON.parse(jsonMatch[0]);
+    throw new Error('AI returned invalid JSON. Try again or use manual profile setup.');
   }
 
   return {
-    name: String(parsed.name || ''),
-    email: String(parsed.email || ''),
-    phone: parsed.phone ? String(parsed.phone) : undefined,
-    location: parsed.location ? String(parsed.location) : undefined,
-    linkedin_url: parsed.linkedin_url ? String(parsed.linkedin_url) : undefined,
-    github_url: parsed.github_url ? String(parsed.github_url) : undefined,
-    portfolio_url: parsed.portfolio_url ? String(parsed.portfolio_url) : undefined,
-    skills: Array.isArray(parsed.skills) ? parsed.skills.map(String) : [],
+    name: asString(parsed.name) ?? '',
+    email: asString(parsed.email) ?? '',
+    phone: asString(parsed.phone),
+    location: asString(parsed.location),
+    linkedin_url: asString(parsed.linkedin_url),
+    github_url: asString(parsed.github_url),
+    portfolio_url: asString(parsed.portfolio_url),
+    skills: asStringArray(parsed.skills),
     experience: parseExperience(parsed.experience),
     education: parseEducation(parsed.education),
   };
@@ -80,25 +76,26 @@ export async function extractProfileFromResume(
 
 function parseExperience(raw: unknown): Experience[] {
   if (!Array.isArray(raw)) return [];
-  return raw.map((exp: Record<string, unknown>) => ({
-    company: String(exp.company || ''),
-    title: String(exp.title || ''),
-    location: exp.location ? String(exp.location) : undefined,
-    start_date: String(exp.start_date || ''),
-    end_date: exp.end_date ? String(exp.end_date) : undefined,
-    description: exp.description ? String(exp.description) : undefined,
-    highlights: Array.isArray(exp.highlights) ? exp.highlights.map(String) : [],
+  return raw.filter(isRecord).map((exp) => ({
+    company: asString(exp.company) ?? '',
+    title: asString(exp.title) ?? '',
+    location: asString(exp.location),
+    start_date: asString(exp.start_date) ?? '',
+    end_date: asString(exp.end_date),
+    description: asString(exp.description),
+    highlights: asStringArray(exp.highlights),
+    skills: asStringArray(exp.skills),
   }));
 }
 
 function parseEducation(raw: unknown): Education[] {
   if (!Array.isArray(raw)) return [];
-  return raw.map((edu: Record<string, unknown>) => ({
-    institution: String(edu.institution || ''),
-    degree: String(edu.degree || ''),
-    field: edu.field ? String(edu.field) : undefined,
-    start_date: edu.start_date ? String(edu.start_date) : undefined,
-    end_date: edu.end_date ? String(edu.end_date) : undefined,
-    gpa: edu.gpa ? String(edu.gpa) : undefined,
+  return raw.filter(isRecord).map((edu) => ({
+    institution: asString(edu.institution) ?? '',
+    degree: asString(edu.degree) ?? '',
+    field: asString(edu.field),
+    start_date: asString(edu.start_date),
+    end_date: asString(edu.end_date),
+    gpa: asString(edu.gpa),
   }));
 }
@@ -0,0 +1,208 @@
+import { describe, expect, test } from 'bun:test';
+import {
+  aiConfigFor,
+  composeSystemPrompt,
+  describeProviderError,
+  isRetryableProviderError,
+  modelForTask,
+  testProvider,
+  withFallback,
+} from './provider';
+import type { AIConfig, AIProvider, AIProviderType } from '../types';
+
+function stubProvider(name: AIProviderType, reply: string | Error): AIProvider & { calls: number } {
+  return {
+    name,
+    calls: 0,
+    async generateText() {
+      this.calls++;
+      if (reply instanceof Error) throw reply;
+      return reply;
+    },
+    async isAvailable() {
+      return !(reply instanceof Error);
+    },
+  };
+}
+
+describe('withFallback', () => {
+  test('returns the single provider unwrapped', () => {
+    const only = stubProvider('openai', 'hello');
+    expect(withFallback([only])).toBe(only);
+  });
+
+  test('throws when no providers are given', () => {
+    expect(() => withFallback([])).toThrow();
+  });
+
+  test('falls through to the next provider when the first one errors', async () => {
+    const primary = stubProvider('openai', new Error('insufficient_quota'));
+    const secondary = stubProvider('anthropic', 'from anthropic');
+
+    const provider = withFallback([primary, secondary]);
+    const text = await provider.generateText('Write a cover letter');
+
+    expect(text).toBe('from anthropic');
+    expect(primary.calls).toBe(1);
+    expect(secondary.calls).toBe(1);
+  });
+
+  test('does not call fallbacks when the primary succeeds', async () => {
+    const primary = stubProvider('openai', 'from openai');
+    const secondary = stubProvider('anthropic', 'from anthropic');
+
+    const text = await withFallback([primary, secondary]).generateText('prompt');
+
+    expect(text).toBe('from openai');
+    expect(secondary.calls).toBe(0);
+  });
+
+  test('reports every failure when the whole chain fails', async () => {
+    const provider = withFallback([
+      stubProvider('openai', new Error('quota')),
+      stubProvider('ollama', new Error('connection refused')),
+    ]);
+
+    await expect(provider.generateText('prompt')).rejects.toThrow(
+      'All AI providers failed (openai: quota; ollama: connection refused)'
+    );
+  });
+
+  test('does not fall back on configuration errors', async () => {
+    const primary = stubProvider('openai', new Error('Incorrect API key provided'));
+    const secondary = stubProvider('anthropic', 'from anthropic');
+
+    await expect(withFallback([primary, secondary]).generateText('prompt')).rejects.toThrow('Incorrect API key');
+    expect(secondary.calls).toBe(0);
+  });
+
+  test('is available when any provider in the chain is available', async () => {
+    const provider = withFallback([
+      stubProvider('openai', new Error('down')),
+      stubProvider('ollama', 'ok'),
+    ]);
+
+    expect(await provider.isAvailable()).toBe(true);
+  });
+});
+
+describe('isRetryableProviderError', () => {
+  function apiError(statusCode: number): Error {
+    return Object.assign(new Error(`HTTP ${statusCode}`), { statusCode });
+  }
+
+  test('retries rate limits, server errors and network failures', () => {
+    expect(isRetryableProviderError(apiError(429))).toBe(true);
+    expect(isRetryableProviderError(apiError(503))).toBe(true);
+    expect(isRetryableProviderError(new Error('fetch failed'))).toBe(true);
+    expect(isRetryableProviderError(new Error('AI request timed out after 60s'))).toBe(true);
+    expect(isRetryableProviderError(Object.assign(new Error('Failed after 3 attempts'), { lastError: apiError(500) }))).toBe(true);
+  });
+
+  test('does not retry auth, missing model or unknown errors', () => {
+    expect(isRetryableProviderError(apiError(401))).toBe(false);
+    expect(isRetryableProviderError(apiError(404))).toBe(false);
+    expect(isRetryableProviderError(new Error('Missing OPENAI_API_KEY environment variable'))).toBe(false);
+    expect(isRetryableProviderError(new Error('Ollama model "llama3" is not installed. Run: ollama pull llama3'))).toBe(false);
+    expect(isRetryableProviderError(new Error('Invalid JSON schema'))).toBe(false);
+  });
+});
+
+describe('modelForTask', () => {
+  const config: AIConfig = {
+    provider: 'openai',
+    model: 'gpt-5.2',
+    resumeModel: 'gpt-5.2-pro',
+    coverLetterModel: 'gpt-5-mini',
+  };
+
+  test('uses the per-task model when set', () => {
+    expect(modelForTask(config, 'resume')).toBe('gpt-5.2-pro');
+    expect(modelForTask(config, 'cover-letter')).toBe('gpt-5-mini');
+  });
+
+  test('falls back to the default model', () => {
+    expect(modelForTask(config)).toBe('gpt-5.2');
+    expect(modelForTask({ provider: 'openai', model: 'gpt-5.2' }, 'resume')).toBe('gpt-5.2');
+    expect(modelForTask({ ...config, coverLetterModel: '  ' }, 'cover-letter')).toBe('gpt-5.2');
+  });
+});
+
+describe('composeSystemPrompt', () => {
+  test('puts the persona ahead of the task instructions', () => {
+    expect(composeSystemPrompt('Write in a warm, plain voice.', 'Return ONLY valid JSON.')).toBe(
+      'Write in a warm, plain voice.\n\nReturn ONLY valid JSON.'
+    );
+  });
+
+  test('uses whichever part is set', () => {
+    expect(composeSystemPrompt(undefined, 'Task')).toBe('Task');
+    expect(composeSystemPrompt('Persona', undefined)).toBe('Persona');
+    expect(composeSystemPrompt('  ', undefined)).toBeUndefined();
+  });
+});
+
+describe('testProvider', () => {
+  test('reports the round-trip latency on success', async () => {
+    let clock = 1_000;
+    const provider: AIProvider = {
+      name: 'openai',
+      isAvailable: async () => true,
+      generateText: async () => {
+        clock += 420;
+        return 'hello';
+      },
+    };
+
+    expect(await testProvider(provider, () => clock)).toEqual({ success: true, latencyMs: 420 });
+  });
+
+  test('reports the provider error', async () => {
+    const result = await testProvider(stubProvider('openai', new Error('401 Incorrect API key provided')));
+
+    expect(result.success).toBe(false);
+  });
+});
+
+describe('describeProviderError', () => {
+  test('explains a rejected key', () => {
+    expect(describeProviderError('401 Incorrect API key provided')).toStartWith('API key rejected');
+  });
+
+  test('explains an unreachable local server', () => {
+    expect(
+      describeProviderError('fetch failed: ECONNREFUSED', { provider: 'ollama', model: 'llama3.2', baseUrl: 'http://localhost:11434' })
+    ).toStartWith('Could not reach ollama at http://localhost:11434');
+  });
+
+  test('explains a wrong model name', () => {
+    expect(describeProviderError('The model `gpt-9` does not exist', { provider: 'openai', model: 'gpt-9' })).toStartWith(
+      'Model "gpt-9" not found'
+    );
+  });
+
+  test('passes other errors through', () => {
+    expect(describeProviderError('Rate limit reached')).toBe('Rate limit reached');
+  });
+});
+
+describe('aiConfigFor', () => {
+  test('fills in the default model and local URL', () => {
+    expect(aiConfigFor('ollama')).toEqual({
+      provider: 'ollama',
+      model: 'llama3.2',
+      baseUrl: 'http://localhost:11434',
+      temperature: 0.7,
+    });
+  });
+
+  test('keeps a chosen model and leaves cloud providers without a URL', () => {
+    const config = aiConfigFor('openai', ' gpt-4o-mini ');
+    expect(config.model).toBe('gpt-4o-mini');
+    expect(config.baseUrl).toBeUndefined();
+  });
+
+  test('treats a blank model as the default', () => {
+    expect(aiConfigFor('lmstudio', '  ').model).toBe('local-model');
+  });
+});
@@ -2,8 +2,12 @@ import { generateText } from 'ai';
 import { createOpenAI } from '@ai-sdk/openai';
 import { createAnthropic } from '@ai-sdk/anthropic';
 import { createGoogleGenerativeAI } from '@ai-sdk/google';
-import type { AIProvider, AIProviderType, AIConfig } from '../types';
+import { DEFAULT_CONFIG, type AIProvider, type AIProviderType, type AIConfig } from '../types';
 import { configRepository } from '../db/repositories/config';
+import { logger, isVerbose } from '../utils/logger';
+import { writeAILog } from './debug-log';
+import { ensureOllamaModel } from './ollama';
+import { createAIFetch } from './http';
 
 // Model mappings for each provider
 const MODEL_DEFAULTS: Record<AIProviderType, string> = {
@@ -20,6 +24,11 @@ const API_KEY_ENV_VARS: Partial<Record<AIProviderType, string>> = {
   google: 'GOOGLE_API_KEY',
 };
 
+const LOCAL_BASE_URLS: Partial<Record<AIProviderType, string>> = {
+  ollama: 'http://localhost:11434',
+  lmstudio: 'http://localhost:1234',
+};
+
 function createModel(config: AIConfig) {
   const modelId = config.model || MODEL_DEFAULTS[config.provider];
 
@@ -31,22 +40,27 @@ function createModel(config: AIConfig) {
     );
   }
 
+  const aiFetch = createAIFetch(config);
+
   switch (config.provider) {
     case 'openai': {
       const openai = createOpenAI({
         apiKey: process.env.OPENAI_API_KEY,
+        fetch: aiFetch,
       });
       return openai(modelId);
     }
     case 'anthropic': {
       const anthropic = createAnthropic({
         apiKey: process.env.ANTHROPIC_API_KEY,
+        fetch: aiFetch,
       });
       return anthropic(modelId);
     }
     case 'google': {
       const google = createGoogleGenerativeAI({
         apiKey: process.env.GOOGLE_API_KEY,
+        fetch: aiFetch,
       });
       return google(modelId);
     }
@@ -59,6 +73,7 @@ function createModel(config: AIConfig) {
       const ollama = createOpenAI({
         baseURL: baseUrl,
         apiKey: 'ollama', // Ollama doesn't require an API key
+        fetch: aiFetch,
       });
       return ollama(modelId);
     }
@@ -71,6 +86,7 @@ function createModel(config: AIConfig) {
       const lmstudio = createOpenAI({
         baseURL: lmBaseUrl,
         apiKey: 'lmstudio', // LMStudio doesn't require an API key
+        fetch: aiFetch,
       });
       return lmstudio(modelId);
     }
@@ -79,6 +95,15 @@ function createModel(config: AIConfig) {
   }
 }
 
+/**
+ * Put the user's persona first so each task's format rules (e.g. "return
+ * only JSON") come last and take precedence.
+ */
+export function composeSystemPrompt(persona: string | undefined, taskPrompt: string | undefined): string | undefined {
+  const parts = [persona?.trim(), taskPrompt?.trim()].filter((part): part is string => Boolean(part));
+  return parts.length > 0 ? parts.join('\n\n') : undefined;
+}
+
 class UnifiedAIProvider implements AIProvider {
   name: AIProviderType;
   private config: AIConfig;
@@ -89,6 +114,13 @@ class UnifiedAIProvider implements AIProvider {
   }
 
   async isAvailable(): Promise<boolean> {
+    try {
+      await this.preflight();
+    } catch (error) {
+      warnOnce(error instanceof Error ? error.message : 'Unknown error');
+      return false;
+    }
+
     try {
       const model = createModel(this.config);
       await generateText({
@@ -102,42 +134,228 @@ class UnifiedAIProvider implements AIProvider {
     }
   }
 
-  async generateText(prompt: string, systemPrompt?: string): Promise<string> {
+  async generateText(prompt: string, taskPrompt?: string): Promise<string> {
+    await this.preflight();
     const model = createModel(this.config);
+    const systemPrompt = composeSystemPrompt(this.config.systemPrompt, taskPrompt);
+    const startedAt = Date.now();
+
+    try {
+      const result = await generateText({
+        model,
+        system: systemPrompt,
+        prompt,
+        temperature: this.config.temperature ?? 0.7,
+      });
+
+      this.debugLog({ system: systemPrompt, prompt, response: result.text, durationMs: Date.now() - startedAt });
+      return result.text;
+    } catch (error) {
+      const msg = error instanceof Error ? error.message : 'Unknown error';
+      this.debugLog({ system: systemPrompt, prompt, error: msg, durationMs: Date.now() - startedAt });
+      throw error;
+    }
+  }
 
-    const result = await generateText({
-      model,
-      system: systemPrompt,
-      prompt,
-      temperature: this.config.temperature ?? 0.7,
+  private debugLog(entry: { system?: string; prompt: string; response?: string; error?: string; durationMs: number }): void {
+    if (!this.config.debug && !isVerbose()) return;
+    writeAILog({
+      provider: this.config.provider,
+      model: this.config.model || MODEL_DEFAULTS[this.config.provider],
+      ...entry,
     });
+  }
+
+  private async preflight(): Promise<void> {
+    if (this.config.provider === 'ollama') {
+      await ensureOllamaModel(this.config.model || MODEL_DEFAULTS.ollama, this.config);
+    }
+  }
+}
+
+const warnedMessages = new Set<string>();
+
+function warnOnce(message: string): void {
+  if (warnedMessages.has(message)) return;
+  warnedMessages.add(message);
+  logger.warning(message);
+}
+
+/**
+ * Whether another provider might succeed where this one failed: network
+ * errors, timeouts, rate limits/quota and 5xx responses. Bad API keys, unknown
+ * models and other configuration problems are not, since switching providers
+ * would only hide them.
+ */
+export function isRetryableProviderError(error: unknown): boolean {
+  // The AI SDK wraps the last attempt's error once its own retries run out
+  const lastError = (error as { lastError?: unknown } | null)?.lastError;
+  if (lastError) return isRetryableProviderError(lastError);
+
+  const status = (error as { statusCode?: unknown } | null)?.statusCode;
+  if (typeof status === 'number') {
+    return status === 408 || status === 429 || status >= 500;
+  }
+
+  const text = (error instanceof Error ? error.message : String(error)).toLowerCase();
+  if (/\b(401|403)\b|api.?key|unauthorized|authentication|not installed|model.*(not found|does not exist)|unknown model/.test(text)) {
+    return false;
+  }
+  return /econnrefused|econnreset|etimedout|enotfound|fetch failed|connection refused|cannot reach|unable to connect|timed? ?out|rate.?limit|quota|overloaded|\b(408|429|5\d\d)\b/.test(
+    text
+  );
+}
+
+/**
+ * Wraps several providers so generation falls through to the next one when a
+ * provider fails in a way another might not (see isRetryableProviderError).
+ * Configuration errors are rethrown so they aren't hidden by a fallback's output.
+ */
+class FallbackAIProvider implements AIProvider {
+  name: AIProviderType;
+  private providers: AIProvider[];
 
-    return result.text;
+  constructor(providers: AIProvider[]) {
+    this.providers = providers;
+    this.name = providers[0].name;
   }
+
+  async isAvailable(): Promise<boolean> {
+    for (const provider of this.providers) {
+      if (await provider.isAvailable()) {
+        return true;
+      }
+    }
+    return false;
+  }
+
+  async generateText(prompt: string, systemPrompt?: string): Promise<string> {
+    const failures: string[] = [];
+
+    for (const provider of this.providers) {
+      try {
+        const text = await provider.generateText(prompt, systemPrompt);
+        if (failures.length > 0) {
+          logger.info(`Generated with fallback provider: ${provider.name}`);
+        } else {
+          logger.debug(`Generated with provider: ${provider.name}`);
+        }
+        return text;
+      } catch (error) {
+        const msg = error instanceof Error ? error.message : 'Unknown error';
+        if (!isRetryableProviderError(error)) {
+          logger.warning(`AI provider ${provider.name} failed: ${msg}`);
+          throw error;
+        }
+        logger.debug(`AI provider ${provider.name} failed: ${msg}`);
+        failures.push(`${provider.name}: ${msg}`);
+      }
+    }
+
+    throw new Error(`All AI providers failed (${failures.join('; ')})`);
+  }
+}
+
+export function withFallback(providers: AIProvider[]): AIProvider {
+  if (providers.length === 0) {
+    throw new Error('At least one AI provider is required');
+  }
+  return providers.length === 1 ? providers[0] : new FallbackAIProvider(providers);
 }
 
-export function createAIProvider(config?: AIConfig): AIProvider {
+/** Document types that can use their own model */
+export type AITask = 'resume' | 'cover-letter';
+
+/** The model configured for a task, falling back to ai.model. */
+export function modelForTask(config: AIConfig, task?: AITask): string {
+  const override = task === 'resume' ? config.resumeModel : task === 'cover-letter' ? config.coverLetterModel : undefined;
+  return override?.trim() || config.model;
+}
+
+/**
+ * Pass a task to use ai.resumeModel / ai.coverLetterModel for the primary
+ * provider. Fallbacks keep their own models.
+ */
+export function createAIProvider(config?: AIConfig, task?: AITask): AIProvider {
   const aiConfig = config ?? configRepository.loadAppConfig().ai;
-  return new UnifiedAIProvider(aiConfig);
+  const chain: AIProvider[] = [new UnifiedAIProvider({ ...aiConfig, model: modelForTask(aiConfig, task) })];
+
+  for (const fallback of aiConfig.fallbacks ?? []) {
+    chain.push(
+      new UnifiedAIProvider({
+        provider: fallback.provider,
+        model: fallback.model ?? '',
+        baseUrl: fallback.baseUrl,
+        temperature: aiConfig.temperature,
+        systemPrompt: aiConfig.systemPrompt,
+        debug: aiConfig.debug,
+        timeout: aiConfig.timeout,
+        proxy: aiConfig.proxy,
+        insecureSkipVerify: aiConfig.insecureSkipVerify,
+      })
+    );
+  }
+
+  return withFallback(chain);
 }
 
 export function getAvailableProviders(): AIProviderType[] {
   return ['openai', 'anthropic', 'google', 'ollama', 'lmstudio'];
 }
 
-export async function testProvider(provider: AIProvider): Promise<{ success: boolean; error?: string }> {
+export function getDefaultModel(provider: AIProviderType): string {
+  return MODEL_DEFAULTS[provider];
+}
+
+/** The environment variable a cloud provider reads its API key from; undefined for local ones. */
+export function getApiKeyEnvVar(provider: AIProviderType): string | undefined {
+  return API_KEY_ENV_VARS[provider];
+}
+
+/** A fresh AI config for a provider, filling in its default model and local URL. */
+export function aiConfigFor(provider: AIProviderType, model?: string): AIConfig {
+  return {
+    provider,
+    model: model?.trim() || MODEL_DEFAULTS[provider],
+    baseUrl: LOCAL_BASE_URLS[provider],
+    temperature: DEFAULT_CONFIG.ai.temperature,
+  };
+}
+
+/** Turn common provider failures into something the user can act on. */
+export function describeProviderError(message: string, config?: Pick<AIConfig, 'provider' | 'model' | 'baseUrl'>): string {
+  const text = message.toLowerCase();
+  if (/\b401\b|invalid.*api.?key|incorrect api key|unauthorized|authentication/.test(text)) {
+    return `API key rejected (${message})`;
+  }
+  if (/econnrefused|fetch failed|unable to connect|enotfound|connection refused/.test(text)) {
+    const where = config?.baseUrl ? ` at ${config.baseUrl}` : '';
+    return `Could not reach ${config?.provider ?? 'the provider'}${where}; is it running? (${message})`;
+  }
+  if (/model.*(not found|does not exist)|\b404\b|unknown model/.test(text)) {
+    return `Model ${config?.model ? `"${config.model}" ` : ''}not found (${message})`;
+  }
+  return message;
+}
+
+export async function testProvider(
+  provider: AIProvider,
+  now: () => number = Date.now
+): Promise<{ success: boolean; error?: string; latencyMs?: number }> {
   try {
     const available = await provider.isAvailable();
     if (!available) {
       return { success: false, error: 'Provider is not available or not running' };
     }
 
+    const startedAt = now();
     const response = await provider.generateText('Say "hello" and nothing else.');
+    const latencyMs = now() - startedAt;
     if (!response || response.length === 0) {
-      return { success: false, error: 'Provider returned empty response' };
+      return { success: false, error: 'Provider returned empty response', latencyMs };
     }
 
-    return { success: true };
+    return { success: true, latencyMs };
   } catch (error) {
     return {
       success: false,
@@ -0,0 +1,127 @@
+import { describe, expect, test } from 'bun:test';
+import {
+  AIResponseError,
+  parseJsonObject,
+  parseJsonArray,
+  asString,
+  asStringArray,
+} from './response';
+import { evaluateJobFit } from './job-matcher';
+import { extractJobDataWithAI } from './job-extractor';
+import type { AIProvider, JobData, Profile } from '../types';
+
+function replyWith(text: string): AIProvider {
+  return {
+    name: 'openai',
+    generateText: async () => text,
+    isAvailable: async () => true,
+  };
+}
+
+const profile: Profile = {
+  name: 'Ada Lovelace',
+  email: 'ada@example.com',
+  skills: ['TypeScript'],
+  experience: [],
+  education: [],
+};
+
+const jobData: JobData = {
+  url: 'https://example.com/job',
+  platform: 'generic',
+  title: 'Engineer',
+  company: 'Acme',
+  description: 'Build things',
+  requirements: [],
+  qualifications: [],
+  form_fields: [],
+  custom_questions: [],
+};
+
+describe('parseJsonObject', () => {
+  test('parses fenced JSON', () => {
+    expect(parseJsonObject('```json\n{"score": 80}\n```')).toEqual({ score: 80 });
+  });
+
+  test('extracts an object surrounded by prose', () => {
+    expect(parseJsonObject('Sure! Here it is: {"a": 1} Hope that helps.')).toEqual({ a: 1 });
+  });
+
+  test('throws a descriptive error for non-JSON text', () => {
+    expect(() => parseJsonObject('I cannot help with that.')).toThrow(AIResponseError);
+    expect(() => parseJsonObject('I cannot help with that.')).toThrow('AI returned invalid JSON');
+  });
+
+  test('throws when the JSON is an array instead of an object', () => {
+    expect(() => parseJsonObject('[1, 2, 3]')).toThrow('Expected a JSON object from AI but got an array');
+  });
+
+  test('throws on empty responses', () => {
+    expect(() => parseJsonObject('')).toThrow('(empty response)');
+  });
+});
+
+describe('parseJsonArray', () => {
+  test('parses an array surrounded by prose', () => {
+    expect(parseJsonArray('Answers:\n[{"id": "a"}]')).toEqual([{ id: 'a' }]);
+  });
+
+  test('throws when the model returns an error object', () => {
+    expect(() => parseJsonArray('{"error": {"message": "rate limited"}}')).toThrow(
+      'Expected a JSON array from AI but got an object'
+    );
+  });
+});
+
+describe('asString / asStringArray', () => {
+  test('asString ignores objects and blanks', () => {
+    expect(asString({ nested: true })).toBeUndefined();
+    expect(asString('   ')).toBeUndefined();
+    expect(asString(42)).toBe('42');
+  });
+
+  test('asStringArray drops non-scalar entries and wraps bare strings', () => {
+    expect(asStringArray(['Go', null, { x: 1 }, 3])).toEqual(['Go', '3']);
+    expect(asStringArray('TypeScript')).toEqual(['TypeScript']);
+    expect(asStringArray(undefined)).toEqual([]);
+  });
+});
+
+describe('hardened AI callers', () => {
+  test('evaluateJobFit falls back to a neutral result on malformed JSON', async () => {
+    const result = await evaluateJobFit(replyWith('{"score": 80, "reasoning": '), profile, jobData);
+
+    expect(result.score).toBe(50);
+    expect(result.strongMatches).toEqual([]);
+  });
+
+  test('evaluateJobFit tolerates wrongly-typed list fields', async () => {
+    const result = await evaluateJobFit(
+      replyWith('{"score": 72, "strongMatches": "TypeScript", "missingSkills": {"a": 1}}'),
+      profile,
+      jobData
+    );
+
+    expect(result.score).toBe(72);
+    expect(result.strongMatches).toEqual(['TypeScript']);
+    expect(result.missingSkills).toEqual([]);
+  });
+
+  test('extractJobDataWithAI rejects with a descriptive error on an error payload', async () => {
+    await expect(
+      extractJobDataWithAI(replyWith('["unexpected"]'), '<html></html>', jobData.url)
+    ).rejects.toThrow(AIResponseError);
+  });
+
+  test('extractJobDataWithAI skips fields with unexpected shapes', async () => {
+    const result = await extractJobDataWithAI(
+      replyWith('{"title": "Engineer", "company": {"name": "Acme"}, "requirements": [1, null]}'),
+      '<html></html>',
+      jobData.url
+    );
+
+    expect(result.title).toBe('Engineer');
+    expect(result.company).toBeUndefined();
+    expect(result.requirements).toEqual(['1']);
+  });
+});
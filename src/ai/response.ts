@@ -0,0 +1,114 @@
+/**
+ * Helpers for turning free-form model output into typed data.
+ * Models wrap JSON in markdown fences, add chatter around it, or return an
+ * entirely different shape (error objects, strings instead of arrays), so
+ * nothing here trusts the response structure.
+ */
+
+export class AIResponseError extends Error {
+  readonly raw: string;
+
+  constructor(message: string, raw: string) {
+    super(message);
+    this.name = 'AIResponseError';
+    this.raw = raw;
+  }
+}
+
+export function stripCodeFences(response: string): string {
+  return response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
+}
+
+function preview(text: string): string {
+  const flat = text.replace(/\s+/g, ' ').trim();
+  if (!flat) return '(empty response)';
+  return flat.length > 80 ? `"${flat.slice(0, 80)}..."` : `"${flat}"`;
+}
+
+function describeShape(value: unknown): string {
+  if (value === null) return 'null';
+  if (Array.isArray(value)) return 'an array';
+  if (typeof value === 'object') return 'an object';
+  return `a ${typeof value}`;
+}
+
+function parseEnclosed(response: string, open: string, close: string): unknown {
+  const cleaned = stripCodeFences(response);
+
+  try {
+    return JSON.parse(cleaned);
+  } catch {
+    // Fall through and look for the JSON block inside surrounding prose
+  }
+
+  const start = cleaned.indexOf(open);
+  const end = cleaned.lastIndexOf(close);
+  if (start !== -1 && end > start) {
+    try {
+      return JSON.parse(cleaned.slice(start, end + 1));
+    } catch {
+      // Reported below
+    }
+  }
+
+  throw new AIResponseError(`AI returned invalid JSON: ${preview(cleaned)}`, response);
+}
+
+export function isRecord(value: unknown): value is Record<string, unknown> {
+  return typeof value === 'object' && value !== null && !Array.isArray(value);
+}
+
+/**
+ * Parse a response that is expected to contain a single JSON object.
+ */
+export function parseJsonObject(response: string): Record<string, unknown> {
+  const parsed = parseEnclosed(response, '{', '}');
+  if (!isRecord(parsed)) {
+    throw new AIResponseError(
+      `Expected a JSON object from AI but got ${describeShape(parsed)}`,
+      response
+    );
+  }
+  return parsed;
+}
+
+/**
+ * Parse a response that is expected to contain a JSON array.
+ */
+export function parseJsonArray(response: string): unknown[] {
+  const parsed = parseEnclosed(response, '[', ']');
+  if (!Array.isArray(parsed)) {
+    throw new AIResponseError(
+      `Expected a JSON array from AI but got ${describeShape(parsed)}`,
+      response
+    );
+  }
+  return parsed;
+}
+
+/**
+ * Read a scalar field as a trimmed string. Returns undefined for missing,
+ * empty, or non-scalar values.
+ */
+export function asString(value: unknown): string | undefined {
+  if (typeof value === 'string') {
+    const trimmed = value.trim();
+    return trimmed ? trimmed : undefined;
+  }
+  if (typeof value === 'number' || typeof value === 'boolean') {
+    return String(value);
+  }
+  return undefined;
+}
+
+/**
+ * Read a list field as strings, dropping entries that aren't scalars.
+ * A bare string is treated as a one-item list.
+ */
+export function asStringArray(value: unknown): string[] {
+  if (!Array.isArray(value)) {
+    const single = asString(value);
+    return single ? [single] : [];
+  }
+  return value.map(asString).filter((item): item is string => item !== undefined);
+}
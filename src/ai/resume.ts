@@ -76,6 +76,7 @@ ${profile.experience
 ${exp.location ? `${exp.location} | ` : ''}${exp.start_date} - ${exp.end_date ?? 'Present'}
 ${exp.description ?? ''}
 ${exp.highlights.length > 0 ? exp.highlights.map((h) => `- ${h}`).join('\n') : ''}
+${exp.skills?.length ? `Skills used: ${exp.skills.join(', ')}` : ''}
 `
   )
   .join('\n')}
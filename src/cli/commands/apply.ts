@@ -5,27 +5,76 @@ import {
   validateUrls,
   readUrlsFromFile,
   getSupportedPlatforms,
-  normalizeUrl,
 } from '../../utils/url-parser';
 import { profileRepository } from '../../db/repositories/profile';
 import { applicationRepository } from '../../db/repositories/application';
 import { configRepository } from '../../db/repositories/config';
 import { logger, chalk } from '../../utils/logger';
-import { applicationQueue } from '../../core/queue';
+import { applicationQueue, selectUrlsToApply } from '../../core/queue';
+import { validateReadyToApply } from '../../core/readiness';
+import { parseMinScore } from '../../core/fit-bands';
+import { checkApplicationCaps, formatCapHit, type ApplicationCapHit } from '../../core/rate-cap';
 import { existsSync } from 'fs';
 import { extractTextFromFile } from '../../utils/document-extractor';
 import { createAIProvider } from '../../ai/provider';
 import { extractProfileFromResume } from '../../ai/profile-extractor';
-import { DEFAULT_CONFIG } from '../../types';
+import { APPLY_METHODS, DEFAULT_CONFIG, type ApplyMethod } from '../../types';
+import { reviewApplication } from '../prompts/review';
+import { selectJobUrls } from '../prompts/select-jobs';
+import { chooseBaseResume } from '../../core/base-resume';
+import { parseSalaryOption } from '../../core/preferences';
+import { confirmSubmission } from '../prompts/submit';
+import { formatProgress, progressState } from '../../utils/progress';
 
 export const applyCommand = new Command('apply')
   .description('Apply to job(s)')
   .argument('[urls...]', 'Job URL(s) to apply to')
-  .option('-f, --file <path>', 'Read URLs from file (one per line)')
+  .option('-f, --file <path>', 'Read URLs from a file (one per line, or .csv/.json with a url column)')
   .option('-d, --dry-run', 'Generate documents without submitting')
   .option('-r, --resume', 'Resume interrupted bulk application')
+  .option('--retry-failed', 'Retry the jobs that failed in the last bulk run (and finish any left pending)')
   .option('--auto', 'Skip confirmations and apply with smart defaults')
-  .action(async (urls: string[], options: { file?: string; dryRun?: boolean; resume?: boolean; auto?: boolean }) => {
+  .option('--explain', 'Show how the fit score was calculated')
+  .option('-i, --interactive', 'Review the resume and cover letter before each submission')
+  .option('-p, --preview', 'Fill the form in the browser but stop before submitting')
+  .option('--no-cache', 'Scrape job pages again instead of reusing recent results')
+  .option('--remote-only', 'Skip jobs that are not fully remote (default: your remote_only preference)')
+  .option('--min-salary <amount>', 'Skip jobs whose posted salary tops out below this (annual; "none" to ignore your minimum)')
+  .option('--force', 'Apply again even if you already have an application for the job')
+  .option('--base-resume <file>', 'Tailor from this resume (PDF, DOCX, MD, TXT) instead of the one in your profile')
+  .option('--min-score <score>', 'Skip jobs whose fit score is below this (default: application.minFitScore)')
+  .option('--select', 'Pick which of the given URLs to apply to before starting')
+  .option('--method <method>', `How you send applications autoply doesn't submit (${APPLY_METHODS.join(', ')})`, 'manual')
+  .action(async (urls: string[], options: { file?: string; dryRun?: boolean; resume?: boolean; retryFailed?: boolean; auto?: boolean; explain?: boolean; interactive?: boolean; preview?: boolean; cache: boolean; remoteOnly?: boolean; minSalary?: string; force?: boolean; select?: boolean; minScore?: string; method: string; baseResume?: string }) => {
+    if (options.auto && options.interactive) {
+      logger.error('--auto and --interactive cannot be used together.');
+      process.exit(1);
+    }
+    if (options.preview && options.dryRun) {
+      logger.error('--preview fills the form in a browser; --dry-run skips the browser. Pick one.');
+      process.exit(1);
+    }
+    let minSalary: number | undefined;
+    try {
+      // "none" or 0 turns the profile's minimum off for this run
+      if (options.minSalary !== undefined) minSalary = parseSalaryOption(options.minSalary) ?? 0;
+    } catch (error) {
+      logger.error(error instanceof Error ? error.message : 'Invalid --min-salary');
+      process.exit(1);
+    }
+    if (!(APPLY_METHODS as readonly string[]).includes(options.method)) {
+      logger.error(`Invalid --method: ${options.method}. Use one of: ${APPLY_METHODS.join(', ')}`);
+      process.exit(1);
+    }
+    const method = options.method as ApplyMethod;
+    let minScore: number | undefined;
+    try {
+      minScore = options.minScore !== undefined ? parseMinScore(options.minScore) : undefined;
+    } catch (error) {
+      logger.error(error instanceof Error ? error.message : 'Invalid --min-score');
+      process.exit(1);
+    }
+
     // Check for profile
     let profile = profileRepository.findFirst();
     if (!profile) {
@@ -76,8 +125,29 @@ export const applyCommand = new Command('apply')
       }
     }
 
+    // Swap in a different base resume for this run only; the saved profile is untouched
+    let baseResumeSource: string;
+    try {
+      ({ profile, source: baseResumeSource } = await chooseBaseResume(profile, options.baseResume));
+    } catch (error) {
+      logger.error(`Could not read --base-resume: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      process.exit(1);
+    }
+    if (options.baseResume) {
+      logger.info(`Using base resume from ${baseResumeSource}`);
+    }
+
     // Handle resume mode
-    if (options.resume) {
+    if (options.retryFailed) {
+      const persistedInfo = applicationQueue.getPersistedInfo();
+      if (!persistedInfo || persistedInfo.failed + persistedInfo.pending === 0) {
+        logger.info('No failed applications to retry.');
+        process.exit(0);
+      }
+      applicationQueue.load();
+      const retrying = applicationQueue.requeueFailed();
+      logger.info(`Retrying ${retrying.length} failed job(s) from ${persistedInfo.savedAt}`);
+    } else if (options.resume) {
       const persistedInfo = applicationQueue.getPersistedInfo();
       if (persistedInfo && persistedInfo.pending > 0) {
         logger.info(
@@ -98,8 +168,14 @@ export const applyCommand = new Command('apply')
           logger.error(`File not found: ${options.file}`);
           process.exit(1);
         }
-        const fileUrls = await readUrlsFromFile(options.file);
-        allUrls = [...allUrls, ...fileUrls];
+        try {
+          const fileUrls = await readUrlsFromFile(options.file);
+          allUrls = [...allUrls, ...fileUrls];
+        } catch (error) {
+          const msg = error instanceof Error ? error.message : 'Unknown error';
+          logger.error(`Could not read ${options.file}: ${msg}`);
+          process.exit(1);
+        }
       }
 
       // Check if we have URLs
@@ -130,40 +206,56 @@ export const applyCommand = new Command('apply')
       }
 
       // Filter out duplicates and already-applied URLs
-      const seen = new Set<string>();
-      const newUrls: typeof valid = [];
-      const skippedUrls: { url: string; reason: string }[] = [];
-
-      for (const v of valid) {
-        const normalized = normalizeUrl(v.url);
-        if (seen.has(normalized)) {
-          skippedUrls.push({ url: v.url, reason: 'Duplicate in current batch' });
-        } else if (applicationRepository.existsByUrl(normalized) || applicationRepository.existsByUrl(v.url)) {
-          skippedUrls.push({ url: v.url, reason: 'Already applied' });
-          seen.add(normalized);
-        } else {
-          seen.add(normalized);
-          newUrls.push({ ...v, url: normalized });
-        }
-      }
+      const selection = selectUrlsToApply(
+        valid.map((v) => v.url),
+        (url) => applicationRepository.existsByUrl(url),
+        { force: options.force }
+      );
 
-      if (skippedUrls.length > 0) {
-        logger.info(`Skipping ${skippedUrls.length} URL(s):`);
-        for (const s of skippedUrls) {
+      if (selection.skipped.length > 0) {
+        logger.info(`Skipping ${selection.skipped.length} URL(s):`);
+        for (const s of selection.skipped) {
           logger.debug(`  ${s.url} — ${s.reason}`);
         }
       }
+      if (selection.reapplying.length > 0) {
+        logger.warning(`Re-applying to ${selection.reapplying.length} job(s) you already applied to (--force)`);
+      }
 
-      if (newUrls.length === 0) {
+      if (selection.urls.length === 0) {
         logger.info('All URLs have already been applied to or are duplicates.');
         process.exit(0);
       }
 
+      if (options.select && selection.urls.length > 1) {
+        selection.urls = await selectJobUrls(selection.urls);
+        if (selection.urls.length === 0) {
+          logger.info('No jobs selected.');
+          process.exit(0);
+        }
+      }
+
       // Add to queue for persistence
-      applicationQueue.addMany(newUrls.map((v) => v.url));
+      applicationQueue.addMany(selection.urls);
       applicationQueue.persist();
     }
 
+    // In --auto mode nobody is watching, so catch missing profile data before any browser work
+    if (options.auto && !options.dryRun) {
+      const issues = new Set<string>();
+      for (const item of applicationQueue.getPending()) {
+        validateReadyToApply(item.url, profile).forEach((issue) => issues.add(issue));
+      }
+      if (issues.size > 0) {
+        logger.error('Not ready to apply:');
+        for (const issue of issues) {
+          logger.error(`  - ${issue}`);
+        }
+        logger.info('Fix these with "autoply profile edit" and run the command again.');
+        process.exit(1);
+      }
+    }
+
     const pendingCount = applicationQueue.getPending().length;
     logger.info(`Processing ${pendingCount} job(s)...`);
 
@@ -174,7 +266,15 @@ export const applyCommand = new Command('apply')
 
     // Process applications
     const results = [];
+    let capHit: ApplicationCapHit | null = null;
     while (applicationQueue.hasNext()) {
+      if (!options.dryRun) {
+        capHit = checkApplicationCaps(configRepository.loadAppConfig().application, (since) =>
+          applicationRepository.countSubmittedSince(since)
+        );
+        if (capHit) break;
+      }
+
       const item = applicationQueue.getNext()!;
       applicationQueue.updateStatus(item.id, 'processing');
 
@@ -182,9 +282,22 @@ export const applyCommand = new Command('apply')
         dryRun: options.dryRun,
         profile,
         autoMode: options.auto,
+        explain: options.explain,
+        reviewApplication: options.interactive ? reviewApplication : undefined,
+        // --auto is unattended, so only ask when someone is watching
+        confirmSubmit: options.auto ? undefined : confirmSubmission,
+        preview: options.preview,
+        noCache: !options.cache,
+        remoteOnly: options.remoteOnly,
+        minSalary,
+        minScore,
+        method,
+        baseResumeSource,
+        retryApplicationId: item.result?.id,
       });
 
       results.push(result);
+      const done = progressState(results.length, results.length + applicationQueue.getPending().length);
 
       if (result.success) {
         applicationQueue.updateStatus(item.id, 'completed');
@@ -192,11 +305,23 @@ export const applyCommand = new Command('apply')
         logger.success(
           `Completed: ${result.application?.job_title} at ${result.application?.company}`
         );
+      } else if (result.skipped) {
+        applicationQueue.updateStatus(item.id, 'skipped', result.error);
+        logger.info(`Skipped: ${result.error}`);
+      } else if (result.notSubmitted) {
+        // The user said no; the pending record is there if they change their mind
+        applicationQueue.updateStatus(item.id, 'skipped', result.error);
+        applicationQueue.setResult(item.id, result.application);
       } else {
         applicationQueue.updateStatus(item.id, 'failed', result.error);
+        // Remember the failed record so --retry-failed updates it instead of adding another
+        if (result.application) applicationQueue.setResult(item.id, result.application);
         logger.error(`Failed: ${result.error}`);
       }
 
+      if (done.total > 1) {
+        logger.info(chalk.dim(formatProgress(done)));
+      }
       logger.newline();
 
       // Rate limit between applications
@@ -211,16 +336,33 @@ export const applyCommand = new Command('apply')
       }
     }
 
-    // Clear the persisted queue on completion
-    applicationQueue.clear();
+    if (capHit) {
+      // Leave the rest of the queue saved so --resume picks it up once the window passes
+      logger.error(formatCapHit(capHit));
+      logger.info(`${applicationQueue.getPending().length} job(s) left. Run "autoply apply --resume" later to continue.`);
+    } else if (applicationQueue.getFailed().length > 0) {
+      // Keep the failures on disk so --retry-failed can pick them up
+      applicationQueue.pruneCompleted();
+    } else {
+      // Clear the persisted queue on completion
+      applicationQueue.clear();
+    }
 
     // Summary
     logger.header('Summary');
     const successful = results.filter((r) => r.success);
-    const failed = results.filter((r) => !r.success);
+    const skipped = results.filter((r) => r.skipped);
+    const notSubmitted = results.filter((r) => r.notSubmitted);
+    const failed = results.filter((r) => !r.success && !r.skipped && !r.notSubmitted);
 
     logger.keyValue('Total', results.length.toString());
     logger.keyValue('Successful', chalk.green(successful.length.toString()));
+    if (skipped.length > 0) {
+      logger.keyValue('Skipped', chalk.yellow(skipped.length.toString()));
+    }
+    if (notSubmitted.length > 0) {
+      logger.keyValue('Not submitted', chalk.yellow(notSubmitted.length.toString()));
+    }
     logger.keyValue('Failed', failed.length > 0 ? chalk.red(failed.length.toString()) : '0');
 
     if (successful.length > 0) {
@@ -233,11 +375,29 @@ export const applyCommand = new Command('apply')
       }
     }
 
+    if (skipped.length > 0) {
+      logger.newline();
+      console.log(chalk.bold('Skipped:'));
+      for (const result of skipped) {
+        console.log(`  ${chalk.yellow('-')} ${result.error}`);
+      }
+    }
+
+    if (notSubmitted.length > 0) {
+      logger.newline();
+      console.log(chalk.bold('Not submitted:'));
+      for (const result of notSubmitted) {
+        console.log(`  ${chalk.yellow('-')} ${result.application?.job_title} at ${result.application?.company} (#${result.application?.id}, pending)`);
+      }
+    }
+
     if (failed.length > 0) {
       logger.newline();
       console.log(chalk.bold('Failed:'));
       for (const result of failed) {
         console.log(`  ${chalk.red('✖')} ${result.error}`);
       }
+      logger.newline();
+      logger.info('Run "autoply apply --retry-failed" to try the failed jobs again.');
     }
   });
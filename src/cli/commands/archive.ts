@@ -0,0 +1,41 @@
+import { Command } from 'commander';
+import { existsSync, readFileSync, writeFileSync } from 'fs';
+import { resolve } from 'path';
+import { buildArchive, parseArchive, restoreArchive } from '../../core/archive';
+import { logger } from '../../utils/logger';
+
+export const exportAllCommand = new Command('export-all')
+  .description('Export profiles, applications, generated documents and settings to a JSON archive')
+  .option('-o, --output <path>', 'Output file path', './autoply-archive.json')
+  .action((options: { output: string }) => {
+    const outputPath = resolve(options.output);
+    const archive = buildArchive();
+    writeFileSync(outputPath, JSON.stringify(archive, null, 2));
+
+    logger.success(`Exported to ${outputPath}`);
+    logger.keyValue('Profiles', String(archive.profiles.length));
+    logger.keyValue('Applications', String(archive.applications.length));
+    logger.keyValue('Documents', String(archive.documents.length));
+  });
+
+export const importAllCommand = new Command('import-all')
+  .description('Restore a JSON archive from export-all into an empty database')
+  .argument('<file>', 'Archive file path')
+  .action((file: string) => {
+    if (!existsSync(file)) {
+      logger.error(`File not found: ${file}`);
+      process.exit(1);
+    }
+
+    try {
+      const summary = restoreArchive(parseArchive(readFileSync(file, 'utf-8')));
+      logger.success('Archive imported.');
+      logger.keyValue('Profiles', String(summary.profiles));
+      logger.keyValue('Applications', String(summary.applications));
+      logger.keyValue('Documents', String(summary.documents));
+      logger.keyValue('Settings', String(summary.settings));
+    } catch (error) {
+      logger.error(`Import failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      process.exit(1);
+    }
+  });
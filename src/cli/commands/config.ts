@@ -1,7 +1,7 @@
 import { Command } from 'commander';
-import { configRepository } from '../../db/repositories/config';
+import { configRepository, getConfigPath } from '../../db/repositories/config';
 import { logger, chalk } from '../../utils/logger';
-import { getAvailableProviders, testProvider, createAIProvider } from '../../ai/provider';
+import { getAvailableProviders, testProvider, createAIProvider, describeProviderError } from '../../ai/provider';
 
 export const configCommand = new Command('config')
   .description('Manage configuration');
@@ -37,10 +37,14 @@ configCommand
     const config = configRepository.loadAppConfig();
 
     logger.header('Configuration');
+    logger.keyValue('File', getConfigPath());
+    logger.newline();
 
     console.log(chalk.bold('AI Settings:'));
     logger.keyValue('  Provider', config.ai.provider);
     logger.keyValue('  Model', config.ai.model);
+    if (config.ai.resumeModel) logger.keyValue('  Resume Model', config.ai.resumeModel);
+    if (config.ai.coverLetterModel) logger.keyValue('  Cover Letter Model', config.ai.coverLetterModel);
     if (config.ai.baseUrl) logger.keyValue('  Base URL', config.ai.baseUrl);
     logger.keyValue('  Temperature', config.ai.temperature?.toString() ?? '0.7');
 
@@ -107,18 +111,21 @@ configCommand
   .description('Test the current AI provider configuration')
   .action(async () => {
     const config = configRepository.loadAppConfig();
-    logger.info(`Testing ${config.ai.provider} provider...`);
+    logger.info(`Testing ${config.ai.provider} provider (${config.ai.model || 'default model'})...`);
 
     try {
       const provider = createAIProvider();
       const result = await testProvider(provider);
 
       if (result.success) {
-        logger.success('AI provider is working correctly!');
+        logger.success(`AI provider is working correctly! (round trip ${result.latencyMs}ms)`);
       } else {
-        logger.error(`AI provider test failed: ${result.error}`);
+        logger.error(`AI provider test failed: ${describeProviderError(result.error ?? 'Unknown error', config.ai)}`);
+        process.exit(1);
       }
     } catch (error) {
-      logger.error(`Failed to test provider: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      const msg = error instanceof Error ? error.message : 'Unknown error';
+      logger.error(`Failed to test provider: ${describeProviderError(msg, config.ai)}`);
+      process.exit(1);
     }
   });
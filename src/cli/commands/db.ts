@@ -0,0 +1,67 @@
+import { Command } from 'commander';
+import { getDbPath } from '../../db';
+import { checkDatabase, isHealthy, pruneStaleData, repairDatabase } from '../../db/maintenance';
+import { parseDuration } from '../../utils/duration';
+import { logger } from '../../utils/logger';
+
+export const dbCommand = new Command('db')
+  .description('Database maintenance');
+
+dbCommand
+  .command('check')
+  .description('Check the database for corruption and orphaned rows')
+  .option('--repair', 'Delete orphaned rows and re-enable foreign keys')
+  .action((options: { repair?: boolean }) => {
+    logger.info(`Checking ${getDbPath()}`);
+    const check = checkDatabase();
+
+    if (isHealthy(check)) {
+      logger.success('No problems found.');
+      return;
+    }
+
+    for (const message of check.integrityErrors) {
+      logger.error(`Integrity: ${message}`);
+    }
+    for (const violation of check.foreignKeyViolations) {
+      logger.warning(`Orphaned row: ${violation.table} #${violation.rowid} (missing ${violation.parent})`);
+    }
+
+    if (options.repair && check.foreignKeyViolations.length > 0) {
+      const removed = repairDatabase();
+      logger.success(`Removed ${removed} orphaned row(s).`);
+    } else if (check.foreignKeyViolations.length > 0) {
+      logger.info('Run "autoply db check --repair" to remove orphaned rows.');
+    }
+
+    if (check.integrityErrors.length > 0) {
+      logger.info('The database file is damaged. Export what you can with "autoply export-all" and import it into a fresh data directory.');
+      process.exit(1);
+    }
+  });
+
+dbCommand
+  .command('prune')
+  .description('Delete cached job pages and generated documents older than a window that you never applied to')
+  .requiredOption('--older-than <age>', 'Age cutoff, e.g. 30d, 2w, 12h')
+  .option('-n, --dry-run', 'Only report what would be deleted')
+  .action((options: { olderThan: string; dryRun?: boolean }) => {
+    let ageMs: number;
+    try {
+      ageMs = parseDuration(options.olderThan);
+    } catch (error) {
+      logger.error(error instanceof Error ? error.message : 'Invalid --older-than');
+      process.exit(1);
+    }
+
+    const before = new Date(Date.now() - ageMs);
+    const result = pruneStaleData(before, { dryRun: options.dryRun });
+    const summary = `${result.cachedJobs} cached job page(s) and ${result.documents} generated document(s) from before ${before.toLocaleDateString()}`;
+
+    if (options.dryRun) {
+      logger.info(`Would delete ${summary}.`);
+    } else {
+      logger.success(`Deleted ${summary}.`);
+    }
+  });
+
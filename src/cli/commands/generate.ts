@@ -1,8 +1,18 @@
 import { Command } from 'commander';
-import { applicationOrchestrator } from '../../core/application';
-import { parseJobUrl, getSupportedPlatforms } from '../../utils/url-parser';
+import { applicationOrchestrator, type GenerateDocumentsOptions } from '../../core/application';
+import { parseJobUrl, getSupportedPlatforms, readUrlsFromFile, validateUrls, normalizeUrl } from '../../utils/url-parser';
+import { generatePackages } from '../../core/batch-generate';
+import { resolveOutputDir } from '../../core/output-path';
+import { loadJobData } from '../../core/job-cache';
+import { formatProgress, progressState } from '../../utils/progress';
 import { profileRepository } from '../../db/repositories/profile';
-import { logger } from '../../utils/logger';
+import { configRepository } from '../../db/repositories/config';
+import { applicationRepository } from '../../db/repositories/application';
+import { parseApplicationId } from '../../core/history-view';
+import { documentRepository, type DocumentType } from '../../db/repositories/document';
+import { copyToClipboard, ClipboardUnavailableError } from '../../utils/clipboard';
+import { logger, chalk } from '../../utils/logger';
+import { askCoverLetterRefinement } from '../prompts/cover-letter';
 import { existsSync, mkdirSync } from 'fs';
 import { resolve } from 'path';
 
@@ -12,24 +22,46 @@ export const generateCommand = new Command('generate')
 generateCommand
   .command('resume <url>')
   .description('Generate a tailored resume for a job posting')
-  .option('-o, --output <path>', 'Output file path', './resume.pdf')
-  .action(async (url: string, options: { output: string }) => {
-    await generateDocument(url, options.output, 'resume');
+  .option('-o, --output <path>', 'Output file path (default: a {company}-{job}-resume.pdf in application.outputDir)')
+  .option('--no-cache', 'Scrape the job page again instead of reusing a recent result')
+  .option('--clipboard', 'Also copy the resume text to the clipboard')
+  .action(async (url: string, options: { output?: string; cache: boolean; clipboard?: boolean }) => {
+    await generateDocument(url, options.output, 'resume', { noCache: !options.cache }, options.clipboard);
   });
 
 generateCommand
   .command('cover-letter <url>')
   .description('Generate a cover letter for a job posting')
-  .option('-o, --output <path>', 'Output file path', './cover_letter.pdf')
-  .action(async (url: string, options: { output: string }) => {
-    await generateDocument(url, options.output, 'cover-letter');
+  .option('-o, --output <path>', 'Output file path (default: a {company}-{job}-cover-letter.pdf in application.outputDir)')
+  .option('-e, --edit', 'Refine the letter interactively before saving')
+  .option('--no-cache', 'Scrape the job page again instead of reusing a recent result')
+  .option('--clipboard', 'Also copy the letter text to the clipboard')
+  .option('--max-chars <n>', "Warn if the letter is longer than the form's character limit")
+  .option('--trim', 'With --max-chars, ask the AI to shorten an over-long letter')
+  .action(async (url: string, options: { output?: string; edit?: boolean; cache: boolean; clipboard?: boolean; maxChars?: string; trim?: boolean }) => {
+    const maxChars = options.maxChars !== undefined ? Number(options.maxChars) : undefined;
+    if (maxChars !== undefined && (!Number.isInteger(maxChars) || maxChars <= 0)) {
+      logger.error(`Invalid --max-chars: ${options.maxChars}`);
+      process.exit(1);
+    }
+    if (options.trim && maxChars === undefined) {
+      logger.error('--trim needs --max-chars to know how short to go.');
+      process.exit(1);
+    }
+    await generateDocument(url, options.output, 'cover-letter', {
+      askRefinement: options.edit ? askCoverLetterRefinement : undefined,
+      noCache: !options.cache,
+      maxChars,
+      trim: options.trim,
+    }, options.clipboard);
   });
 
 generateCommand
   .command('both <url>')
   .description('Generate both resume and cover letter')
-  .option('-d, --output-dir <path>', 'Output directory', '.')
-  .action(async (url: string, options: { outputDir: string }) => {
+  .option('-d, --output-dir <path>', 'Output directory (default: application.outputDir)')
+  .option('--no-cache', 'Scrape the job page again instead of reusing a recent result')
+  .action(async (url: string, options: { outputDir?: string; cache: boolean }) => {
     const profile = profileRepository.findFirst();
     if (!profile) {
       logger.error('No profile found. Run "autoply init" first.');
@@ -43,13 +75,21 @@ generateCommand
       process.exit(1);
     }
 
-    const outputDir = resolve(options.outputDir);
-    if (!existsSync(outputDir)) {
-      mkdirSync(outputDir, { recursive: true });
+    let outputDir: string;
+    if (options.outputDir) {
+      outputDir = resolve(options.outputDir);
+      if (!existsSync(outputDir)) {
+        mkdirSync(outputDir, { recursive: true });
+      }
+    } else {
+      outputDir = resolveOutputDir(configRepository.loadAppConfig().application.outputDir);
     }
 
     try {
-      const result = await applicationOrchestrator.generateDocuments(url, outputDir, 'both');
+      const result = await applicationOrchestrator.generateDocuments(url, outputDir, 'both', {
+        noCache: !options.cache,
+        jobFilenames: !options.outputDir,
+      });
 
       logger.newline();
       logger.success('Documents generated successfully!');
@@ -65,10 +105,131 @@ generateCommand
     }
   });
 
+generateCommand
+  .command('batch')
+  .description('Generate a resume and cover letter for every job in a file, one folder per job')
+  .requiredOption('-f, --file <path>', 'File of job URLs (one per line, or .csv/.json with a url column)')
+  .option('-d, --output-dir <path>', 'Directory to create the job folders in (default: application.outputDir)')
+  .option('--no-cache', 'Scrape job pages again instead of reusing recent results')
+  .action(async (options: { file: string; outputDir?: string; cache: boolean }) => {
+    const profile = profileRepository.findFirst();
+    if (!profile) {
+      logger.error('No profile found. Run "autoply init" first.');
+      process.exit(1);
+    }
+
+    let urls: string[];
+    try {
+      urls = await readUrlsFromFile(options.file);
+    } catch (error) {
+      logger.error(`Could not read ${options.file}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      process.exit(1);
+    }
+
+    const { valid, invalid } = validateUrls(urls);
+    for (const inv of invalid) {
+      logger.warning(`Skipping ${inv.url}: ${inv.error}`);
+    }
+    if (valid.length === 0) {
+      logger.error('No valid URLs to process.');
+      process.exit(1);
+    }
+
+    const baseDir = options.outputDir
+      ? resolve(options.outputDir)
+      : resolveOutputDir(configRepository.loadAppConfig().application.outputDir);
+    const platforms = new Map(valid.map((v) => [v.url, v.platform]));
+
+    const results = await generatePackages(valid.map((v) => v.url), baseDir, {
+      loadJob: async (url) => (await loadJobData(url, platforms.get(url)!, { noCache: !options.cache })).jobData,
+      generate: (jobData, dir) => applicationOrchestrator.generateDocumentsForJob(profile, jobData, dir, 'both'),
+      onProgress: (index, total, url) => {
+        logger.newline();
+        logger.info(`${chalk.dim(formatProgress(progressState(index - 1, total)))} ${url}`);
+      },
+    });
+
+    const failed = results.filter((r) => !r.success);
+    logger.newline();
+    logger.header('Summary');
+    for (const result of results) {
+      if (result.success) {
+        console.log(`  ${chalk.green('✓')} ${result.dir}`);
+      } else {
+        console.log(`  ${chalk.red('✗')} ${result.url} ${chalk.dim(result.error ?? '')}`);
+      }
+    }
+    logger.newline();
+    logger.info(`${results.length - failed.length} of ${results.length} job(s) generated in ${baseDir}`);
+    if (failed.length > 0) {
+      process.exit(1);
+    }
+  });
+
+generateCommand
+  .command('get <job>')
+  .description('Print the latest generated document for a job URL or application id')
+  .option('-t, --type <type>', 'resume or cover-letter', 'cover-letter')
+  .option('--clipboard', 'Copy the document to the clipboard instead of printing it')
+  .action(async (job: string, options: { type: string; clipboard?: boolean }) => {
+    if (options.type !== 'resume' && options.type !== 'cover-letter') {
+      logger.error(`Invalid --type: ${options.type}. Use resume or cover-letter.`);
+      process.exit(1);
+    }
+
+    let content: string | undefined;
+    try {
+      content = findGeneratedDocument(job, options.type);
+    } catch (error) {
+      logger.error(error instanceof Error ? error.message : String(error));
+      process.exit(1);
+    }
+    if (!content) {
+      logger.error(`No saved ${options.type} for ${job}. Generate one with "autoply generate ${options.type} <url>".`);
+      process.exit(1);
+    }
+
+    if (options.clipboard && await copyOrWarn(content)) return;
+    console.log(content);
+  });
+
+/**
+ * Look up a saved document by application id ("12" or "#12"), or by job URL
+ * (generated documents first, then applications). Throws on a malformed id.
+ */
+function findGeneratedDocument(job: string, type: DocumentType): string | undefined {
+  const field = type === 'resume' ? 'generated_resume' : 'generated_cover_letter';
+
+  // Anything that isn't a URL is taken as an application id
+  if (!job.includes('/')) {
+    return applicationRepository.findById(parseApplicationId(job))?.[field];
+  }
+
+  const saved = documentRepository.findLatest(job, type);
+  if (saved) return saved.content;
+
+  return applicationRepository.findByUrl(normalizeUrl(job)).find((app) => app[field])?.[field];
+}
+
+/** Copy text to the clipboard; warns and returns false when no clipboard tool is available. */
+async function copyOrWarn(text: string): Promise<boolean> {
+  try {
+    const tool = await copyToClipboard(text);
+    logger.success(`Copied to clipboard (${tool}).`);
+    return true;
+  } catch (error) {
+    if (!(error instanceof ClipboardUnavailableError)) throw error;
+    logger.warning(`${error.message}; skipping --clipboard.`);
+    return false;
+  }
+}
+
 async function generateDocument(
   url: string,
-  outputPath: string,
-  type: 'resume' | 'cover-letter'
+  outputPath: string | undefined,
+  type: 'resume' | 'cover-letter',
+  generateOptions: GenerateDocumentsOptions = {},
+  clipboard = false
 ): Promise<void> {
   const profile = profileRepository.findFirst();
   if (!profile) {
@@ -83,15 +244,22 @@ async function generateDocument(
     process.exit(1);
   }
 
-  const resolvedPath = resolve(outputPath);
-  const outputDir = resolvedPath.substring(0, resolvedPath.lastIndexOf('/')) || '.';
-
-  if (!existsSync(outputDir)) {
-    mkdirSync(outputDir, { recursive: true });
+  let outputDir: string;
+  if (outputPath) {
+    const resolvedPath = resolve(outputPath);
+    outputDir = resolvedPath.substring(0, resolvedPath.lastIndexOf('/')) || '.';
+    if (!existsSync(outputDir)) {
+      mkdirSync(outputDir, { recursive: true });
+    }
+  } else {
+    outputDir = resolveOutputDir(configRepository.loadAppConfig().application.outputDir);
   }
 
   try {
-    const result = await applicationOrchestrator.generateDocuments(url, outputDir, type);
+    const result = await applicationOrchestrator.generateDocuments(url, outputDir, type, {
+      ...generateOptions,
+      jobFilenames: !outputPath,
+    });
 
     logger.newline();
     logger.success('Document generated successfully!');
@@ -101,6 +269,11 @@ async function generateDocument(
     } else if (type === 'cover-letter' && result.coverLetterPath) {
       logger.keyValue('Output', result.coverLetterPath);
     }
+
+    const text = type === 'resume' ? result.resume : result.coverLetter;
+    if (clipboard && text) {
+      await copyOrWarn(text);
+    }
   } catch (error) {
     logger.error(`Generation failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
     process.exit(1);
@@ -1,15 +1,34 @@
 import { Command } from 'commander';
+import { readFileSync, writeFileSync } from 'fs';
+import { getDb } from '../../db';
 import { applicationRepository } from '../../db/repositories/application';
 import { logger, chalk } from '../../utils/logger';
-import type { ApplicationStatus } from '../../types';
+import { findDuplicateApplications } from '../../core/dedupe';
+import { importStatuses } from '../../core/status-import';
+import { openUrl } from '../../utils/open-url';
+import {
+  HISTORY_FIELDS,
+  HISTORY_SORT_KEYS,
+  formatUrlList,
+  isHistorySortKey,
+  parseApplicationId,
+  parseHistoryFields,
+  renderHistoryTable,
+  sortApplications,
+} from '../../core/history-view';
+import type { Application, ApplicationStatus } from '../../types';
 
 export const historyCommand = new Command('history')
   .description('View application history')
   .option('-s, --status <status>', 'Filter by status (pending, submitted, failed)')
   .option('-c, --company <name>', 'Filter by company name')
+  .option('-t, --tag <tag>', 'Only applications with this tag')
   .option('-l, --limit <number>', 'Limit number of results', '20')
-  .action((options: { status?: string; company?: string; limit: string }) => {
-    const filters: { status?: ApplicationStatus; company?: string } = {};
+  .option('--fields <list>', `Compact table with these columns (${Object.keys(HISTORY_FIELDS).join(', ')})`)
+  .option('--sort <key>', `Sort by ${HISTORY_SORT_KEYS.join(', ')}`, 'date')
+  .option('--output-urls <path>', 'Write the URLs of all matching applications to a file for apply --file')
+  .action((options: { status?: string; company?: string; tag?: string; limit: string; fields?: string; sort: string; outputUrls?: string }) => {
+    const filters: { status?: ApplicationStatus; company?: string; tag?: string } = {};
 
     if (options.status) {
       if (!['pending', 'submitted', 'failed'].includes(options.status)) {
@@ -23,18 +42,59 @@ export const historyCommand = new Command('history')
       filters.company = options.company;
     }
 
-    const applications = applicationRepository.findAll(filters);
+    if (options.tag) {
+      filters.tag = options.tag;
+    }
+
+    if (!isHistorySortKey(options.sort)) {
+      logger.error(`Invalid sort key. Use: ${HISTORY_SORT_KEYS.join(', ')}`);
+      process.exit(1);
+    }
+
+    let fields: string[] | undefined;
+    if (options.fields) {
+      try {
+        fields = parseHistoryFields(options.fields);
+      } catch (error) {
+        logger.error(error instanceof Error ? error.message : String(error));
+        process.exit(1);
+      }
+    }
+
+    const applications = sortApplications(applicationRepository.findAll(filters), options.sort);
     const limit = parseInt(options.limit, 10);
     const limited = applications.slice(0, limit);
 
     if (applications.length === 0) {
       logger.info('No applications found.');
-      if (filters.status || filters.company) {
+      if (filters.status || filters.company || filters.tag) {
         logger.info('Try removing filters to see all applications.');
       }
       return;
     }
 
+    if (options.outputUrls) {
+      const content = formatUrlList(applications);
+      writeFileSync(options.outputUrls, content);
+      const count = content ? content.trimEnd().split('\n').length : 0;
+      logger.success(`Wrote ${count} URL(s) to ${options.outputUrls}`);
+      logger.info(`Apply with: autoply apply --file ${options.outputUrls}`);
+      return;
+    }
+
+    if (fields) {
+      const [header, ...rows] = renderHistoryTable(limited, fields);
+      console.log(chalk.bold(header));
+      for (const row of rows) {
+        console.log(row);
+      }
+      if (applications.length > limit) {
+        logger.newline();
+        logger.info(`Showing ${limit} of ${applications.length} applications. Use --limit to see more.`);
+      }
+      return;
+    }
+
     logger.header('Application History');
 
     for (const app of limited) {
@@ -50,6 +110,9 @@ export const historyCommand = new Command('history')
       );
       console.log(`  Status: ${statusColor(app.status)}`);
       console.log(`  Platform: ${app.platform}`);
+      if (app.apply_method) {
+        console.log(`  Method: ${app.apply_method}`);
+      }
       console.log(`  URL: ${chalk.dim(app.url)}`);
       if (app.applied_at) {
         console.log(`  Applied: ${new Date(app.applied_at).toLocaleDateString()}`);
@@ -78,6 +141,16 @@ export const historyCommand = new Command('history')
     logger.keyValue('  Submitted', chalk.green(stats.submitted.toString()));
     logger.keyValue('  Pending', chalk.yellow(stats.pending.toString()));
     logger.keyValue('  Failed', chalk.red(stats.failed.toString()));
+
+    const byMethod = applicationRepository.countByMethod();
+    if (byMethod.length > 1) {
+      logger.newline();
+      console.log(chalk.bold('By method:'));
+      for (const row of byMethod) {
+        const rate = row.total > 0 ? Math.round((row.submitted / row.total) * 100) : 0;
+        logger.keyValue(`  ${row.method}`, `${row.total} (${row.submitted} submitted, ${row.failed} failed, ${rate}% success)`);
+      }
+    }
   });
 
 historyCommand
@@ -115,16 +188,86 @@ historyCommand
     }
   });
 
+historyCommand
+  .command('undo')
+  .description('Delete the most recent application record')
+  .option('-y, --yes', 'Skip the confirmation prompt')
+  .action(async (options: { yes?: boolean }) => {
+    const app = applicationRepository.findLatest();
+    if (!app) {
+      logger.info('No applications to undo.');
+      return;
+    }
+
+    console.log(`#${app.id} ${chalk.bold(app.job_title)} at ${chalk.cyan(app.company)} (${app.status})`);
+    console.log(`  ${chalk.dim(app.url)}`);
+    if (app.status === 'submitted') {
+      logger.warning('This application was already sent to the employer. Undo only removes it from your history.');
+    }
+
+    if (!options.yes) {
+      const { confirm } = await import('@inquirer/prompts');
+      const confirmed = await confirm({ message: 'Delete this application?', default: false });
+      if (!confirmed) {
+        logger.info('Cancelled.');
+        return;
+      }
+    }
+
+    applicationRepository.delete(app.id!);
+    logger.success(`Deleted application #${app.id}.`);
+  });
+
+historyCommand
+  .command('dedupe')
+  .description('Merge duplicate applications for the same job posting')
+  .option('-d, --dry-run', 'Show what would be removed without changing anything')
+  .action((options: { dryRun?: boolean }) => {
+    const groups = findDuplicateApplications(applicationRepository.findAll());
+
+    if (groups.length === 0) {
+      logger.info('No duplicate applications found.');
+      return;
+    }
+
+    let removed = 0;
+    for (const group of groups) {
+      const { survivor, duplicates, merged } = group;
+      console.log(`${chalk.bold(survivor.job_title)} at ${chalk.cyan(survivor.company)}`);
+      console.log(`  Keep:   #${survivor.id} (${survivor.status}) ${chalk.dim(survivor.url)}`);
+      for (const dup of duplicates) {
+        console.log(`  Remove: #${dup.id} (${dup.status}) ${chalk.dim(dup.url)}`);
+      }
+
+      if (!options.dryRun) {
+        // All or nothing per job, so a failure can't drop tags or leave a half-merged group
+        getDb().transaction(() => {
+          if (Object.keys(merged).length > 0) {
+            applicationRepository.update(survivor.id!, merged);
+          }
+          for (const dup of duplicates) {
+            // Tags cascade away with the deleted row, so move them first
+            applicationRepository.copyTags(dup.id!, survivor.id!);
+            applicationRepository.delete(dup.id!);
+          }
+        })();
+      }
+      removed += duplicates.length;
+    }
+
+    logger.newline();
+    if (options.dryRun) {
+      logger.info(`Would remove ${removed} duplicate(s) across ${groups.length} job(s). Run without --dry-run to apply.`);
+    } else {
+      logger.success(`Removed ${removed} duplicate(s) across ${groups.length} job(s).`);
+    }
+  });
+
 historyCommand
   .command('show <id>')
   .description('Show details of a specific application')
   .action((id: string) => {
-    const app = applicationRepository.findById(parseInt(id, 10));
-
-    if (!app) {
-      logger.error(`Application #${id} not found.`);
-      process.exit(1);
-    }
+    const app = findApplicationOrExit(id);
 
     logger.header(`Application #${app.id}`);
 
@@ -137,6 +280,14 @@ historyCommand
     if (app.applied_at) {
       logger.keyValue('Applied At', new Date(app.applied_at).toLocaleString());
     }
+    if (app.base_resume_source) {
+      logger.keyValue('Base Resume', app.base_resume_source);
+    }
+
+    const tags = applicationRepository.getTags(app.id!);
+    if (tags.length > 0) {
+      logger.keyValue('Tags', tags.join(', '));
+    }
 
     if (app.error_message) {
       logger.newline();
@@ -144,6 +295,12 @@ historyCommand
       console.log(chalk.red(app.error_message));
     }
 
+    if (app.notes) {
+      logger.newline();
+      console.log(chalk.bold('Notes:'));
+      console.log(app.notes);
+    }
+
     if (app.generated_resume) {
       logger.newline();
       console.log(chalk.bold('Generated Resume:'));
@@ -164,3 +321,132 @@ historyCommand
       }
     }
   });
+
+historyCommand
+  .command('note <id> <text>')
+  .description('Set the notes on an application (--append to add a timestamped line instead)')
+  .option('-a, --append', 'Keep existing notes and add this one on top with a timestamp')
+  .action((id: string, text: string, options: { append?: boolean }) => {
+    const appId = findApplicationOrExit(id).id!;
+    const updated = options.append
+      ? applicationRepository.appendNotes(appId, text)
+      : applicationRepository.update(appId, { notes: text });
+
+    if (!updated) {
+      logger.error(`Application #${appId} not found.`);
+      process.exit(1);
+    }
+    logger.success(`${options.append ? 'Added a note to' : 'Updated notes on'} application #${updated.id}.`);
+  });
+
+historyCommand
+  .command('open <id>')
+  .description('Open the job posting for an application in your browser')
+  .action(async (id: string) => {
+    const app = findApplicationOrExit(id);
+    if (!app.url) {
+      logger.error(`Application #${id} has no job URL.`);
+      process.exit(1);
+    }
+
+    try {
+      await openUrl(app.url);
+      logger.info(`Opened ${app.url}`);
+    } catch (error) {
+      logger.error(error instanceof Error ? error.message : 'Could not open browser');
+      logger.info(`URL: ${app.url}`);
+      process.exit(1);
+    }
+  });
+
+historyCommand
+  .command('import-status <file>')
+  .description('Update statuses in bulk from a CSV with id (or job_id), status and optional notes columns')
+  .action((file: string) => {
+    let rows: ReturnType<typeof importStatuses>;
+    try {
+      rows = importStatuses(readFileSync(file, 'utf-8'));
+    } catch (error) {
+      logger.error(`Could not import ${file}: ${error instanceof Error ? error.message : String(error)}`);
+      process.exit(1);
+    }
+
+    if (rows.length === 0) {
+      logger.info(`No rows found in ${file}.`);
+      return;
+    }
+
+    for (const row of rows) {
+      if (row.updated) {
+        logger.success(`#${row.id} ${row.message}`);
+      } else {
+        logger.warning(`Skipped line ${row.line}: ${row.message}`);
+      }
+    }
+
+    const updated = rows.filter((r) => r.updated).length;
+    logger.newline();
+    logger.info(`Updated ${updated} of ${rows.length} application(s).`);
+  });
+
+const tagCommand = historyCommand
+  .command('tag')
+  .description('Label applications (e.g. dream, backup) and filter history with --tag');
+
+tagCommand
+  .command('add <id> <tags...>')
+  .description('Add one or more tags to an application')
+  .action((id: string, tags: string[]) => {
+    const app = findApplicationOrExit(id);
+    const current = applicationRepository.addTags(app.id!, tags) ?? [];
+    logger.success(`#${app.id} ${app.job_title} at ${app.company}: ${current.join(', ')}`);
+  });
+
+tagCommand
+  .command('remove <id> <tags...>')
+  .description('Remove tags from an application')
+  .action((id: string, tags: string[]) => {
+    const app = findApplicationOrExit(id);
+    const current = applicationRepository.removeTags(app.id!, tags) ?? [];
+    logger.success(`#${app.id} ${app.job_title} at ${app.company}: ${current.length > 0 ? current.join(', ') : 'no tags'}`);
+  });
+
+tagCommand
+  .command('list [id]')
+  .description("List an application's tags, or every tag in use")
+  .action((id?: string) => {
+    if (id) {
+      const app = findApplicationOrExit(id);
+      const tags = applicationRepository.getTags(app.id!);
+      logger.info(tags.length > 0 ? tags.join(', ') : `#${app.id} has no tags.`);
+      return;
+    }
+
+    const counts = applicationRepository.countTags();
+    if (counts.length === 0) {
+      logger.info('No tags yet. Add one with "autoply history tag add <id> <tag>".');
+      return;
+    }
+    for (const { tag, count } of counts) {
+      console.log(`  ${tag} ${chalk.dim(`(${count})`)}`);
+    }
+  });
+
+/** Look up an application by a command-line id, exiting with a clear message if it's malformed or missing. */
+function findApplicationOrExit(id: string): Application {
+  let appId: number;
+  try {
+    appId = parseApplicationId(id);
+  } catch (error) {
+    logger.error(error instanceof Error ? error.message : `Invalid application id: ${id}`);
+    process.exit(1);
+  }
+
+  const app = applicationRepository.findById(appId);
+  if (!app) {
+    logger.error(`Application #${appId} not found. Run "autoply history" to list ids.`);
+    process.exit(1);
+  }
+  return app;
+}
+
@@ -2,13 +2,14 @@ import { Command } from 'commander';
 import { confirm } from '@inquirer/prompts';
 import { profileRepository } from '../../db/repositories/profile';
 import { configRepository } from '../../db/repositories/config';
-import { promptForProfile, promptForPreferences } from '../prompts/profile';
+import { promptForProfile, promptForPreferences, promptForAIConfig } from '../prompts/profile';
 import { logger } from '../../utils/logger';
 import { DEFAULT_CONFIG } from '../../types';
 import { getDb, ensureAutoplyDir, getAutoplyDir } from '../../db';
 import { extractTextFromFile } from '../../utils/document-extractor';
 import { extractProfileFromResume } from '../../ai/profile-extractor';
 import { createAIProvider } from '../../ai/provider';
+import { isPromptCancelled } from '../prompts/cancel';
 
 export const initCommand = new Command('init')
   .description('Initialize Autoply with your profile')
@@ -88,7 +89,8 @@ export const initCommand = new Command('init')
               };
 
               const profile = profileRepository.create(profileData);
-              configRepository.saveAppConfig(DEFAULT_CONFIG);
+              // Keep the provider that just did the extraction
+              configRepository.saveAppConfig({ ...DEFAULT_CONFIG, ai: config.ai });
 
               logger.newline();
               logger.success('Profile created successfully!');
@@ -101,7 +103,7 @@ export const initCommand = new Command('init')
               logger.info(`Data stored in: ${getAutoplyDir()}`);
               logger.newline();
               logger.info('Next steps:');
-              logger.info('  1. Configure AI provider: autoply config set ai.provider ollama');
+              logger.info('  1. Check your setup: autoply profile status');
               logger.info('  2. Apply to a job: autoply apply <job-url>');
               return;
             }
@@ -117,11 +119,13 @@ export const initCommand = new Command('init')
       // Prompt for profile information
       const profileData = await promptForProfile({ resumeText, coverLetterText, aiDefaults: aiExtractedProfile ?? undefined });
 
+      const ai = await promptForAIConfig();
+
       // Create profile
       const profile = profileRepository.create(profileData);
 
-      // Save default config
-      configRepository.saveAppConfig(DEFAULT_CONFIG);
+      // Save default config with the chosen provider
+      configRepository.saveAppConfig({ ...DEFAULT_CONFIG, ai });
 
       logger.newline();
       logger.success('Profile created successfully!');
@@ -129,15 +133,17 @@ export const initCommand = new Command('init')
       logger.keyValue('Name', profile.name);
       logger.keyValue('Email', profile.email);
       logger.keyValue('Skills', profile.skills.join(', ') || 'None');
+      logger.keyValue('Experience', `${profile.experience.length} entries`);
       logger.keyValue('Education', `${profile.education.length} entries`);
+      logger.keyValue('AI provider', `${ai.provider} (${ai.model})`);
       logger.newline();
       logger.info(`Data stored in: ${getAutoplyDir()}`);
       logger.newline();
       logger.info('Next steps:');
-      logger.info('  1. Configure AI provider: autoply config set ai.provider ollama');
+      logger.info('  1. Check your setup: autoply profile status');
       logger.info('  2. Apply to a job: autoply apply <job-url>');
     } catch (error) {
-      if (error instanceof Error && error.message.includes('ExitPromptError')) {
+      if (isPromptCancelled(error)) {
         logger.info('Setup cancelled.');
         return;
       }
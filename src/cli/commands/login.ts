@@ -2,13 +2,24 @@ import { Command } from 'commander';
 import { join } from 'path';
 import { getAutoplyDir } from '../../db';
 import { configRepository } from '../../db/repositories/config';
+import { applyStealth, browserContextOptions, browserLaunchArgs } from '../../scrapers/stealth';
+import { checkLinkedInSession, saveStorageState, type LinkedInSessionCheck } from '../../scrapers/linkedin-session';
+import { logger } from '../../utils/logger';
 
-const STORAGE_STATE_PATH = join(getAutoplyDir(), 'browser-state.json');
+function getStorageStatePath(): string {
+  return join(getAutoplyDir(), 'browser-state.json');
+}
 
 export const loginCommand = new Command('login')
   .description('Login to job platforms and save browser session')
   .argument('[platform]', 'Platform to login to (linkedin, etc.)', 'linkedin')
-  .action(async (platform: string) => {
+  .option('--check', 'Check whether the saved LinkedIn session still works, without logging in')
+  .action(async (platform: string, options: { check?: boolean }) => {
+    if (options.check) {
+      await checkSavedSession(platform);
+      return;
+    }
+
     const urls: Record<string, string> = {
       linkedin: 'https://www.linkedin.com/login',
       greenhouse: 'https://www.greenhouse.io',
@@ -26,26 +37,17 @@ export const loginCommand = new Command('login')
     console.log('Please login manually in the browser window.');
     console.log('The browser will close automatically after you login.\n');
 
+    const browserConfig = configRepository.loadAppConfig().browser;
+    const stealth = browserConfig.stealth !== false;
     const { chromium } = await import('playwright');
     const browser = await chromium.launch({
       headless: false,
-      args: [
-        '--disable-blink-features=AutomationControlled',
-        '--disable-features=IsolateOrigins,site-per-process',
-      ],
-    });
-    const context = await browser.newContext({
-      userAgent:
-        'Mozilla/5.0 (Macintosh; Apple Silicon Mac OS X 14_0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36',
-      viewport: { width: 1920, height: 1080 },
-      locale: Intl.DateTimeFormat().resolvedOptions().locale || 'en-US',
-      timezoneId: Intl.DateTimeFormat().resolvedOptions().timeZone || 'UTC',
+      args: browserLaunchArgs(stealth),
     });
+    const context = await browser.newContext(browserContextOptions(browserConfig));
 
-    // Remove webdriver detection flag
-    await context.addInitScript(() => {
-      Object.defineProperty(navigator, 'webdriver', { get: () => undefined });
-    });
+    // Same masking as the scrapers, so the saved session was created by a browser that looks alike
+    await applyStealth(context, stealth);
 
     const page = await context.newPage();
 
@@ -64,14 +66,14 @@ export const loginCommand = new Command('login')
     }
 
     // Save storage state
-    await context.storageState({ path: STORAGE_STATE_PATH });
-    console.log(`\nSession saved to: ${STORAGE_STATE_PATH}`);
+    await saveStorageState(context, getStorageStatePath());
+    console.log(`\nSession saved to: ${getStorageStatePath()}`);
 
     // Update config to use the storage state
     configRepository.updateAppConfig({
       browser: {
         ...configRepository.loadAppConfig().browser,
-        storageState: STORAGE_STATE_PATH,
+        storageState: getStorageStatePath(),
       },
     });
     console.log('Config updated to use saved session.');
@@ -79,3 +81,43 @@ export const loginCommand = new Command('login')
     await browser.close();
     console.log('\nLogin complete! Your session will be reused for future scraping.');
   });
+
+const CHECK_MESSAGES: Record<LinkedInSessionCheck, string> = {
+  ok: 'LinkedIn session is valid.',
+  missing: 'No saved LinkedIn session. Run "autoply login" to create one.',
+  expired: 'Saved LinkedIn session has expired. Run "autoply login" again.',
+  login: 'LinkedIn sent the saved session to the login page. Run "autoply login" again.',
+  checkpoint: 'LinkedIn is showing a security checkpoint. Run "autoply login" and complete it in the browser.',
+};
+
+async function checkSavedSession(platform: string): Promise<void> {
+  if (platform !== 'linkedin') {
+    logger.error(`--check only supports linkedin (got ${platform}).`);
+    process.exit(1);
+  }
+
+  const browserConfig = configRepository.loadAppConfig().browser;
+  const result = await checkLinkedInSession(browserConfig.storageState, async (url) => {
+    const { chromium } = await import('playwright');
+    const browser = await chromium.launch({ headless: true, args: browserLaunchArgs(browserConfig.stealth !== false) });
+    try {
+      const context = await browser.newContext({
+        ...browserContextOptions(browserConfig),
+        storageState: browserConfig.storageState,
+      });
+      await applyStealth(context, browserConfig.stealth !== false);
+      const page = await context.newPage();
+      await page.goto(url, { waitUntil: 'domcontentloaded', timeout: browserConfig.timeout });
+      return page.url();
+    } finally {
+      await browser.close();
+    }
+  });
+
+  if (result === 'ok') {
+    logger.success(CHECK_MESSAGES.ok);
+  } else {
+    logger.error(CHECK_MESSAGES[result]);
+    process.exit(1);
+  }
+}
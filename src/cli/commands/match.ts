@@ -0,0 +1,68 @@
+import { Command } from 'commander';
+import { parseJobUrl, getSupportedPlatforms } from '../../utils/url-parser';
+import { profileRepository } from '../../db/repositories/profile';
+import { configRepository } from '../../db/repositories/config';
+import { loadJobData } from '../../core/job-cache';
+import { buildMatchReport, formatMatchReport } from '../../core/match-report';
+import { parseMinScore, resolveFitThresholds } from '../../core/fit-bands';
+import { createAIProvider } from '../../ai/provider';
+import { evaluateJobFit } from '../../ai/job-matcher';
+import { logger, createSpinner } from '../../utils/logger';
+
+export const matchCommand = new Command('match')
+  .description('Show how well your profile fits a job before applying')
+  .argument('<url>', 'Job URL to evaluate')
+  .option('--no-cache', 'Scrape the job page again instead of reusing a recent result')
+  .option('--min-score <score>', 'Fit score that counts as a strong match (default: application.minFitScore, or 70)')
+  .action(async (url: string, options: { cache: boolean; minScore?: string }) => {
+    let minScore: number | undefined;
+    try {
+      minScore = options.minScore !== undefined ? parseMinScore(options.minScore) : undefined;
+    } catch (error) {
+      logger.error(error instanceof Error ? error.message : 'Invalid --min-score');
+      process.exit(1);
+    }
+
+    const profile = profileRepository.findFirst();
+    if (!profile) {
+      logger.error('No profile found. Run "autoply init" first.');
+      process.exit(1);
+    }
+
+    const parsed = parseJobUrl(url);
+    if (!parsed.isValid) {
+      logger.error(parsed.error!);
+      logger.info('Supported platforms: ' + getSupportedPlatforms().join(', '));
+      process.exit(1);
+    }
+
+    const spinner = createSpinner('Scraping job...');
+    try {
+      spinner.start();
+      const { jobData, cached } = await loadJobData(url, parsed.platform, { noCache: !options.cache });
+      spinner.succeed(`${cached ? 'Cached' : 'Scraped'}: ${jobData.title} at ${jobData.company}`);
+
+      const provider = createAIProvider();
+      if (!(await provider.isAvailable())) {
+        logger.error('AI provider is not running or configured.');
+        process.exit(1);
+      }
+
+      spinner.start('Evaluating job fit...');
+      const config = configRepository.loadAppConfig();
+      const fit = await evaluateJobFit(provider, profile, jobData, {
+        neutralScore: config.application.neutralFitScore,
+      });
+      spinner.stop();
+
+      const thresholds = resolveFitThresholds(config.application, minScore);
+      logger.newline();
+      for (const line of formatMatchReport(buildMatchReport(jobData, fit, profile.base_resume, thresholds))) {
+        console.log(line);
+      }
+    } catch (error) {
+      spinner.fail('Match failed');
+      logger.error(error instanceof Error ? error.message : 'Unknown error');
+      process.exit(1);
+    }
+  });
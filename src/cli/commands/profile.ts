@@ -2,6 +2,18 @@ import { Command } from 'commander';
 import { profileRepository } from '../../db/repositories/profile';
 import { promptForProfileUpdate } from '../prompts/profile';
 import { logger, chalk } from '../../utils/logger';
+import { addToBlocklist, removeFromBlocklist } from '../../utils/company';
+import { isPromptCancelled } from '../prompts/cancel';
+import { computeProfileCompleteness } from '../../core/profile-completeness';
+import { addSkillToExperience } from '../../utils/experience';
+import {
+  describePreferences,
+  parseSalaryOption,
+  splitListOption,
+  updatePreferences,
+  type PreferenceChanges,
+} from '../../core/preferences';
+import { createAIProvider } from '../../ai/provider';
 
 export const profileCommand = new Command('profile')
   .description('Manage your profile');
@@ -32,15 +44,18 @@ profileCommand
     if (profile.experience.length > 0) {
       logger.newline();
       console.log(chalk.bold('Experience:'));
-      for (const exp of profile.experience) {
-        console.log(`  ${chalk.cyan(exp.title)} at ${exp.company}`);
+      profile.experience.forEach((exp, i) => {
+        console.log(`  ${chalk.dim(`${i + 1}.`)} ${chalk.cyan(exp.title)} at ${exp.company}`);
         console.log(`    ${exp.start_date} - ${exp.end_date ?? 'Present'}`);
+        if (exp.skills?.length) {
+          console.log(`    ${chalk.dim('Skills:')} ${exp.skills.join(', ')}`);
+        }
         if (exp.highlights.length > 0) {
           for (const highlight of exp.highlights.slice(0, 2)) {
             console.log(`    • ${highlight}`);
           }
         }
-      }
+      });
     }
 
     if (profile.education.length > 0) {
@@ -62,11 +77,78 @@ profileCommand
       if (profile.preferences.job_types.length > 0) {
         logger.keyValue('  Job types', profile.preferences.job_types.join(', '));
       }
+      if (profile.preferences.excluded_companies?.length) {
+        logger.keyValue('  Blocked companies', profile.preferences.excluded_companies.join(', '));
+      }
     }
 
     logger.newline();
   });
 
+profileCommand
+  .command('status')
+  .description('Show how complete your profile is and what to fill in next')
+  .action(async () => {
+    const profile = profileRepository.findFirst();
+    if (!profile) {
+      logger.error('No profile found. Run "autoply init" to create one.');
+      process.exit(1);
+    }
+
+    let aiConfigured = false;
+    try {
+      aiConfigured = await createAIProvider().isAvailable();
+    } catch {
+      // Treated as not configured
+    }
+
+    const { percent, checks } = computeProfileCompleteness(profile, { aiConfigured });
+    const color = percent >= 80 ? chalk.green : percent >= 50 ? chalk.yellow : chalk.red;
+
+    logger.header('Profile Completeness');
+    console.log(`  ${color(`${percent}%`)} complete`);
+    logger.newline();
+    for (const check of checks) {
+      console.log(`  ${check.done ? chalk.green('✔') : chalk.red('✖')} ${check.label}`);
+    }
+
+    const nextSteps = checks.filter((check) => !check.done);
+    if (nextSteps.length > 0) {
+      logger.newline();
+      console.log(chalk.bold('Next steps:'));
+      for (const check of nextSteps) {
+        console.log(`  - ${check.nextStep}`);
+      }
+    }
+    logger.newline();
+  });
+
+profileCommand
+  .command('tag <role> <skills...>')
+  .description('Tag skills used in a role (numbered as in "profile show"); recent ones boost job fit')
+  .action((role: string, skills: string[]) => {
+    const profile = profileRepository.findFirst();
+    if (!profile) {
+      logger.error('No profile found. Run "autoply init" to create one.');
+      process.exit(1);
+    }
+
+    const index = Number(role) - 1;
+    if (!Number.isInteger(index) || index < 0 || index >= profile.experience.length) {
+      logger.error(`No role #${role}. Run "autoply profile show" to see your roles (1-${profile.experience.length}).`);
+      process.exit(1);
+    }
+
+    const experience = [...profile.experience];
+    experience[index] = skills
+      .flatMap((skill) => skill.split(','))
+      .reduce((exp, skill) => addSkillToExperience(exp, skill), experience[index]);
+    profileRepository.update(profile.id!, { experience });
+
+    const exp = experience[index];
+    logger.success(`${exp.title} at ${exp.company}: ${exp.skills?.join(', ') || 'no skills'}`);
+  });
+
 profileCommand
   .command('edit')
   .description('Edit your profile')
@@ -82,7 +164,7 @@ profileCommand
       profileRepository.update(profile.id!, updates);
       logger.success('Profile updated successfully!');
     } catch (error) {
-      if (error instanceof Error && error.message.includes('ExitPromptError')) {
+      if (isPromptCancelled(error)) {
         logger.info('Edit cancelled.');
         return;
       }
@@ -121,3 +203,113 @@ profileCommand
     logger.warning('Resume import feature is not yet implemented.');
     logger.info('Please use "autoply init" to create your profile manually.');
   });
+
+const blocklistCommand = profileCommand
+  .command('blocklist')
+  .description('Companies to never apply to (matched case-insensitively, on whole words)');
+
+function updateBlocklist(update: (list: string[]) => string[]): string[] {
+  const profile = profileRepository.findFirst();
+  if (!profile) {
+    logger.error('No profile found. Run "autoply init" to create one.');
+    process.exit(1);
+  }
+  const current = profile.preferences?.excluded_companies ?? [];
+  const next = update(current);
+  if (next !== current) {
+    profileRepository.update(profile.id!, {
+      preferences: { ...profile.preferences, excluded_companies: next },
+    });
+  }
+  return next;
+}
+
+blocklistCommand
+  .command('add <company...>')
+  .description('Block one or more companies')
+  .action((companies: string[]) => {
+    const list = updateBlocklist((current) => companies.reduce(addToBlocklist, current));
+    logger.success(`Blocklist: ${list.join(', ')}`);
+  });
+
+blocklistCommand
+  .command('remove <company...>')
+  .description('Unblock one or more companies')
+  .action((companies: string[]) => {
+    const list = updateBlocklist((current) => companies.reduce(removeFromBlocklist, current));
+    logger.success(list.length > 0 ? `Blocklist: ${list.join(', ')}` : 'Blocklist is empty.');
+  });
+
+blocklistCommand
+  .command('list')
+  .description('Show blocked companies')
+  .action(() => {
+    const list = profileRepository.findFirst()?.preferences?.excluded_companies ?? [];
+    if (list.length === 0) {
+      logger.info('Blocklist is empty.');
+      return;
+    }
+    for (const company of list) {
+      console.log(`  ${company}`);
+    }
+  });
+
+const preferencesCommand = profileCommand
+  .command('preferences')
+  .description('Job-search preferences used to filter and score jobs');
+
+preferencesCommand
+  .command('show')
+  .description('Show your job-search preferences')
+  .action(() => {
+    const profile = profileRepository.findFirst();
+    if (!profile) {
+      logger.error('No profile found. Run "autoply init" to create one.');
+      process.exit(1);
+    }
+    for (const [label, value] of describePreferences(profile.preferences)) {
+      logger.keyValue(label, value);
+    }
+  });
+
+const collect = (value: string, previous: string[]) => [...previous, value];
+
+preferencesCommand
+  .command('set')
+  .description('Change one or more preferences (others are left as they are)')
+  .option('--remote-only', 'Only apply to remote jobs')
+  .option('--no-remote-only', 'Apply to on-site and hybrid jobs too')
+  .option('--min-salary <amount>', 'Minimum annual salary ("none" to clear)')
+  .option('--location <place>', 'Preferred location; repeat or comma-separate for several', collect, [])
+  .option('--job-type <type>', 'Job type, e.g. full-time or contract; repeat or comma-separate', collect, [])
+  .action((options: { remoteOnly?: boolean; minSalary?: string; location: string[]; jobType: string[] }) => {
+    const profile = profileRepository.findFirst();
+    if (!profile) {
+      logger.error('No profile found. Run "autoply init" to create one.');
+      process.exit(1);
+    }
+
+    const changes: PreferenceChanges = { remoteOnly: options.remoteOnly };
+    try {
+      if (options.minSalary !== undefined) changes.minSalary = parseSalaryOption(options.minSalary);
+    } catch (error) {
+      logger.error(error instanceof Error ? error.message : 'Invalid --min-salary');
+      process.exit(1);
+    }
+    if (options.location.length > 0) changes.locations = splitListOption(options.location);
+    if (options.jobType.length > 0) changes.jobTypes = splitListOption(options.jobType);
+
+    if (Object.values(changes).every((value) => value === undefined)) {
+      logger.error('Nothing to change. See "autoply profile preferences set --help".');
+      process.exit(1);
+    }
+
+    const updated = profileRepository.update(profile.id!, {
+      preferences: updatePreferences(profile.preferences, changes),
+    });
+    logger.success('Preferences updated.');
+    for (const [label, value] of describePreferences(updated?.preferences)) {
+      logger.keyValue(label, value);
+    }
+  });
+
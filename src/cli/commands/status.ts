@@ -1,6 +1,6 @@
 import { Command } from 'commander';
 import { applicationRepository } from '../../db/repositories/application';
-import { parseJobUrl } from '../../utils/url-parser';
+import { parseJobUrl, normalizeUrl } from '../../utils/url-parser';
 import { logger, chalk } from '../../utils/logger';
 
 /**
@@ -18,7 +18,12 @@ export const statusCommand = new Command('status')
     }
 
     // Look up existing applications for this URL
-    const applications = applicationRepository.findByUrl(url);
+    // apply stores normalized URLs; older records may have the raw one
+    const normalized = normalizeUrl(url);
+    const applications = applicationRepository.findByUrl(normalized);
+    if (normalized !== url) {
+      applications.push(...applicationRepository.findByUrl(url));
+    }
 
     if (applications.length === 0) {
       logger.info('No application found for this URL.');
@@ -36,6 +41,9 @@ export const statusCommand = new Command('status')
       logger.keyValue('Company', app.company);
       logger.keyValue('Platform', app.platform);
       logger.keyValue('Status', formatStatus(app.status));
+      if (app.apply_method) {
+        logger.keyValue('Method', app.apply_method);
+      }
       logger.keyValue('Created', formatDate(app.created_at));
 
       if (app.applied_at) {
@@ -9,8 +9,14 @@ import { historyCommand } from './commands/history';
 import { loginCommand } from './commands/login';
 import { statusCommand } from './commands/status';
 import { importCommand } from './commands/import';
-import { closeDb } from '../db';
-import { setVerbose } from '../utils/logger';
+import { exportAllCommand, importAllCommand } from './commands/archive';
+import { dbCommand } from './commands/db';
+import { matchCommand } from './commands/match';
+import { closeDb, setAutoplyDir } from '../db';
+import { setConfigPath } from '../db/repositories/config';
+import { setVerbose, logger, setColorEnabled, shouldUseColor } from '../utils/logger';
+import { setIgnoreRobots } from '../utils/robots';
+import { setPageTimeout, closeAllBrowsers } from '../scrapers/base';
 
 const program = new Command();
 
@@ -18,13 +24,36 @@ program
   .name('autoply')
   .description('Automated job application CLI - Apply to jobs with AI-generated resumes')
   .version('1.0.0')
-  .option('-v, --verbose', 'Enable verbose output for debugging');
+  .option('-v, --verbose', 'Enable verbose output for debugging')
+  .option('--data-dir <path>', 'Store data in this directory instead of ~/.autoply (or $AUTOPLY_HOME)')
+  .option('--config <path>', 'Use this config file instead of config.json in the data directory')
+  .option('--timeout <seconds>', 'Page load timeout for scraping and form filling (overrides browser.timeout)')
+  .option('--no-color', 'Disable colored output (also honors NO_COLOR)')
+  .option('--ignore-robots', 'Scrape job pages even if robots.txt disallows them (with browser.respectRobotsTxt on)');
 
 program.hook('preAction', (thisCommand) => {
   const opts = thisCommand.optsWithGlobals();
+  setColorEnabled(opts.color !== false && shouldUseColor(process.env, process.stdout.isTTY));
   if (opts.verbose) {
     setVerbose(true);
   }
+  if (opts.dataDir) {
+    setAutoplyDir(opts.dataDir);
+  }
+  if (opts.ignoreRobots) {
+    setIgnoreRobots(true);
+  }
+  if (opts.config) {
+    setConfigPath(opts.config);
+  }
+  if (opts.timeout !== undefined) {
+    const seconds = Number(opts.timeout);
+    if (!Number.isFinite(seconds) || seconds <= 0) {
+      logger.error(`Invalid --timeout: ${opts.timeout} (expected a number of seconds)`);
+      process.exit(1);
+    }
+    setPageTimeout(seconds * 1000);
+  }
 });
 
 // Register commands
@@ -32,25 +61,38 @@ program.addCommand(initCommand);
 program.addCommand(profileCommand);
 program.addCommand(configCommand);
 program.addCommand(applyCommand);
+program.addCommand(matchCommand);
 program.addCommand(generateCommand);
 program.addCommand(historyCommand);
 program.addCommand(loginCommand);
 program.addCommand(statusCommand);
 program.addCommand(importCommand);
+program.addCommand(exportAllCommand);
+program.addCommand(importAllCommand);
+program.addCommand(dbCommand);
 
 // Cleanup on exit
 process.on('exit', () => {
   closeDb();
 });
 
-process.on('SIGINT', () => {
+let shuttingDown = false;
+
+async function shutdown(exitCode: number): Promise<void> {
+  // A second Ctrl+C skips the cleanup wait
+  if (shuttingDown) process.exit(exitCode);
+  shuttingDown = true;
+  await closeAllBrowsers();
   closeDb();
-  process.exit(0);
+  process.exit(exitCode);
+}
+
+process.on('SIGINT', () => {
+  void shutdown(130);
 });
 
 process.on('SIGTERM', () => {
-  closeDb();
-  process.exit(0);
+  void shutdown(143);
 });
 
 // Parse and execute
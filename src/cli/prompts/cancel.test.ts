@@ -0,0 +1,18 @@
+import { describe, expect, test } from 'bun:test';
+import { isPromptCancelled } from './cancel';
+
+class ExitPromptError extends Error {
+  override name = 'ExitPromptError';
+}
+
+describe('isPromptCancelled', () => {
+  test('recognizes inquirer cancellation by error type', () => {
+    expect(isPromptCancelled(new ExitPromptError('User force closed the prompt with SIGINT'))).toBe(true);
+  });
+
+  test('ignores other errors, even ones that mention the name', () => {
+    expect(isPromptCancelled(new Error('ExitPromptError'))).toBe(false);
+    expect(isPromptCancelled(new Error('Database locked'))).toBe(false);
+    expect(isPromptCancelled('ExitPromptError')).toBe(false);
+  });
+});
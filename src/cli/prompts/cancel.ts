@@ -0,0 +1,8 @@
+/**
+ * True when the user closed an @inquirer prompt (Ctrl+C). Inquirer throws
+ * an ExitPromptError whose message doesn't mention its type, so match on
+ * the error name rather than the text.
+ */
+export function isPromptCancelled(error: unknown): boolean {
+  return error instanceof Error && error.name === 'ExitPromptError';
+}
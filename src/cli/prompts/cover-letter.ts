@@ -0,0 +1,15 @@
+import { input } from '@inquirer/prompts';
+import { logger, chalk } from '../../utils/logger';
+
+/**
+ * Show the current draft and ask how to change it. An empty answer accepts it.
+ */
+export async function askCoverLetterRefinement(coverLetter: string): Promise<string> {
+  logger.header('Cover Letter Draft');
+  console.log(coverLetter);
+  console.log(chalk.dim('─'.repeat(50)));
+
+  return input({
+    message: 'Refinement (e.g. "make it shorter", "mention Python more"), or Enter to accept:',
+  });
+}
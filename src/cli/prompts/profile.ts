@@ -1,6 +1,8 @@
 import { input, confirm, select } from '@inquirer/prompts';
 
-import type { Profile, Education, Preferences, Experience } from '../../types';
+import type { Profile, Education, Preferences, Experience, AIConfig, AIProviderType } from '../../types';
+import { aiConfigFor, getApiKeyEnvVar, getAvailableProviders, getDefaultModel } from '../../ai/provider';
+import { parseSalaryOption } from '../../core/preferences';
 import { extractTextFromFile, validateDocumentPath, getSupportedFormatsDescription } from '../../utils/document-extractor';
 
 type AIExtractedProfile = Omit<Profile, 'id' | 'created_at' | 'updated_at' | 'base_resume' | 'base_cover_letter' | 'preferences'>;
@@ -86,8 +88,26 @@ export async function promptForProfile(options: ProfilePromptOptions = {}): Prom
     }
   }
 
-  // Experience - use AI extracted or empty
+  // Experience - use AI extracted or prompt
   const experience: Experience[] = defaults?.experience ?? [];
+  if (!defaults?.experience?.length) {
+    const addExperience = await confirm({
+      message: 'Add work experience?',
+      default: true,
+    });
+
+    if (addExperience) {
+      let addMore = true;
+      while (addMore) {
+        const exp = await promptForExperience();
+        experience.push(exp);
+        addMore = await confirm({
+          message: 'Add another role?',
+          default: false,
+        });
+      }
+    }
+  }
 
   // Preferences
   const preferences = await promptForPreferences();
@@ -225,6 +245,72 @@ async function promptForEducation(): Promise<Education> {
   };
 }
 
+async function promptForExperience(): Promise<Experience> {
+  const company = await input({
+    message: 'Company:',
+    validate: (v) => (v.length > 0 ? true : 'Required'),
+  });
+
+  const title = await input({
+    message: 'Job title:',
+    validate: (v) => (v.length > 0 ? true : 'Required'),
+  });
+
+  const location = await input({
+    message: 'Location (optional):',
+  });
+
+  const start_date = await input({
+    message: 'Start date (e.g., 2021-03):',
+    validate: (v) => (v.length > 0 ? true : 'Required'),
+  });
+
+  const end_date = await input({
+    message: 'End date (leave empty if current):',
+  });
+
+  const skillsInput = await input({
+    message: 'Skills used in this role (comma-separated, optional):',
+  });
+  const skills = skillsInput
+    .split(',')
+    .map((s) => s.trim())
+    .filter(Boolean);
+
+  return {
+    company,
+    title,
+    location: location || undefined,
+    start_date,
+    end_date: end_date || undefined,
+    highlights: [],
+    skills: skills.length ? skills : undefined,
+  };
+}
+
+export async function promptForAIConfig(): Promise<AIConfig> {
+  const provider = await select<AIProviderType>({
+    message: 'AI provider for tailoring documents and answering questions:',
+    choices: getAvailableProviders().map((value) => ({
+      value,
+      name: getApiKeyEnvVar(value) ? `${value} (needs ${getApiKeyEnvVar(value)})` : `${value} (local)`,
+    })),
+    default: 'ollama',
+  });
+
+  const model = await input({
+    message: 'Model:',
+    default: getDefaultModel(provider),
+  });
+
+  const envVar = getApiKeyEnvVar(provider);
+  if (envVar && !process.env[envVar]) {
+    console.log(`\n  ${envVar} is not set. Add it to your shell before applying: export ${envVar}=your-key\n`);
+  }
+
+  return aiConfigFor(provider, model);
+}
+
 export async function promptForPreferences(): Promise<Preferences> {
   const remote_only = await confirm({
     message: 'Only interested in remote jobs?',
@@ -233,8 +319,16 @@ export async function promptForPreferences(): Promise<Preferences> {
 
   const minSalaryInput = await input({
     message: 'Minimum salary (optional, numbers only):',
+    validate: (value) => {
+      try {
+        parseSalaryOption(value);
+        return true;
+      } catch {
+        return 'Enter a yearly amount like 120000, or leave empty';
+      }
+    },
   });
-  const min_salary = minSalaryInput ? parseInt(minSalaryInput, 10) : undefined;
+  const min_salary = parseSalaryOption(minSalaryInput) ?? undefined;
 
   const locationsInput = await input({
     message: 'Preferred locations (comma-separated, optional):',
@@ -295,6 +389,21 @@ export async function promptForProfileUpdate(
     default: current.location ?? '',
   });
 
+  const linkedin_url = await input({
+    message: 'LinkedIn URL:',
+    default: current.linkedin_url ?? '',
+  });
+
+  const github_url = await input({
+    message: 'GitHub URL:',
+    default: current.github_url ?? '',
+  });
+
+  const portfolio_url = await input({
+    message: 'Portfolio/website URL:',
+    default: current.portfolio_url ?? '',
+  });
+
   const skillsInput = await input({
     message: 'Skills (comma-separated):',
     default: current.skills.join(', '),
@@ -310,6 +419,9 @@ export async function promptForProfileUpdate(
     email,
     phone: phone || undefined,
     location: location || undefined,
+    linkedin_url: linkedin_url || undefined,
+    github_url: github_url || undefined,
+    portfolio_url: portfolio_url || undefined,
     skills,
   };
 }
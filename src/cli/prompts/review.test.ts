@@ -0,0 +1,52 @@
+import { describe, expect, test } from 'bun:test';
+import { reviewApplication, type ReviewChoice, type ReviewPrompts } from './review';
+import type { ApplicationReview } from '../../core/application';
+
+function review(): ApplicationReview {
+  return {
+    jobData: {
+      url: 'https://boards.greenhouse.io/acme/jobs/1',
+      platform: 'greenhouse',
+      title: 'Engineer',
+      company: 'Acme',
+      description: '',
+      requirements: [],
+      qualifications: [],
+      form_fields: [],
+      custom_questions: [],
+    },
+    documents: { resume: '# Ada', coverLetter: 'Dear Acme,' },
+  };
+}
+
+function scripted(choices: ReviewChoice[], edits: string[] = []): ReviewPrompts & { edited: string[] } {
+  const edited: string[] = [];
+  return {
+    edited,
+    choose: async () => choices.shift() ?? 'skip',
+    editCoverLetter: async (letter) => {
+      edited.push(letter);
+      return edits.shift() ?? letter;
+    },
+  };
+}
+
+describe('reviewApplication', () => {
+  test('confirms on submit', async () => {
+    expect(await reviewApplication(review(), scripted(['submit']))).toBe(true);
+  });
+
+  test('declines on skip', async () => {
+    expect(await reviewApplication(review(), scripted(['skip']))).toBe(false);
+  });
+
+  test('editing updates the cover letter and asks again', async () => {
+    const r = review();
+    const prompts = scripted(['edit', 'submit'], ['Dear Acme team,']);
+
+    expect(await reviewApplication(r, prompts)).toBe(true);
+    expect(prompts.edited).toEqual(['Dear Acme,']);
+    expect(r.documents.coverLetter).toBe('Dear Acme team,');
+    expect(r.documents.resume).toBe('# Ada');
+  });
+});
@@ -0,0 +1,68 @@
+import { select } from '@inquirer/prompts';
+import type { ApplicationReview } from '../../core/application';
+import { createAIProvider } from '../../ai/provider';
+import { refineCoverLetterLoop } from '../../ai/cover-letter';
+import { logger, chalk } from '../../utils/logger';
+import { askCoverLetterRefinement } from './cover-letter';
+
+export type ReviewChoice = 'submit' | 'edit' | 'skip';
+
+export interface ReviewPrompts {
+  choose: () => Promise<ReviewChoice>;
+  editCoverLetter: (coverLetter: string) => Promise<string>;
+}
+
+const defaultPrompts: ReviewPrompts = {
+  choose: () =>
+    select<ReviewChoice>({
+      message: 'Submit this application?',
+      choices: [
+        { name: 'Submit', value: 'submit' },
+        { name: 'Edit cover letter', value: 'edit' },
+        { name: 'Skip this job', value: 'skip' },
+      ],
+    }),
+  editCoverLetter: (coverLetter) =>
+    refineCoverLetterLoop(createAIProvider(undefined, 'cover-letter'), coverLetter, askCoverLetterRefinement),
+};
+
+function printReview(review: ApplicationReview): void {
+  const { jobData, documents, fitResult } = review;
+
+  logger.header(`${jobData.title} at ${jobData.company}`);
+  logger.keyValue('Platform', jobData.platform);
+  logger.keyValue('URL', jobData.url);
+  if (jobData.location) logger.keyValue('Location', jobData.location);
+  if (fitResult) logger.keyValue('Fit', `${fitResult.score}% (${fitResult.recommendation})`);
+
+  logger.newline();
+  console.log(chalk.bold('Resume:'));
+  console.log(chalk.dim('─'.repeat(50)));
+  console.log(documents.resume);
+
+  logger.newline();
+  console.log(chalk.bold('Cover Letter:'));
+  console.log(chalk.dim('─'.repeat(50)));
+  console.log(documents.coverLetter);
+  logger.newline();
+}
+
+/**
+ * Show the job and generated documents, then ask whether to submit. Editing
+ * the cover letter updates review.documents and asks again.
+ */
+export async function reviewApplication(
+  review: ApplicationReview,
+  prompts: ReviewPrompts = defaultPrompts
+): Promise<boolean> {
+  printReview(review);
+
+  for (;;) {
+    const choice = await prompts.choose();
+    if (choice === 'submit') return true;
+    if (choice === 'skip') return false;
+
+    const coverLetter = await prompts.editCoverLetter(review.documents.coverLetter);
+    review.documents = { ...review.documents, coverLetter };
+  }
+}
@@ -0,0 +1,27 @@
+import { describe, expect, test } from 'bun:test';
+import { parseNumericSelection, pickSelected } from './select-jobs';
+
+describe('parseNumericSelection', () => {
+  test('parses single numbers and ranges into sorted zero-based indexes', () => {
+    expect(parseNumericSelection('3, 1-2 2', 5)).toEqual([0, 1, 2]);
+  });
+
+  test('blank selects nothing and "all" selects everything', () => {
+    expect(parseNumericSelection('  ', 3)).toEqual([]);
+    expect(parseNumericSelection('ALL', 3)).toEqual([0, 1, 2]);
+  });
+
+  test('rejects out-of-range and malformed entries', () => {
+    expect(() => parseNumericSelection('4', 3)).toThrow('Out of range');
+    expect(() => parseNumericSelection('0', 3)).toThrow('Out of range');
+    expect(() => parseNumericSelection('3-1', 3)).toThrow('Out of range');
+    expect(() => parseNumericSelection('two', 3)).toThrow('Not a number');
+  });
+});
+
+describe('pickSelected', () => {
+  test('keeps chosen items in their original order', () => {
+    const urls = ['a', 'b', 'c', 'd'];
+    expect(pickSelected(urls, parseNumericSelection('4,2', urls.length))).toEqual(['b', 'd']);
+  });
+});
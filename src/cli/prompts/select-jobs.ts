@@ -0,0 +1,63 @@
+import { checkbox, input } from '@inquirer/prompts';
+
+/**
+ * Turn a numeric answer like "1,3-5" into zero-based indexes into a list of
+ * `count` items. Blank means none, "all" means everything. Throws on numbers
+ * outside the list so a typo doesn't silently drop a job.
+ */
+export function parseNumericSelection(answer: string, count: number): number[] {
+  const trimmed = answer.trim().toLowerCase();
+  if (!trimmed) return [];
+  if (trimmed === 'all' || trimmed === '*') {
+    return Array.from({ length: count }, (_, i) => i);
+  }
+
+  const picked = new Set<number>();
+  for (const part of trimmed.split(/[\s,]+/).filter(Boolean)) {
+    const match = part.match(/^(\d+)(?:-(\d+))?$/);
+    if (!match) {
+      throw new Error(`Not a number or range: ${part}`);
+    }
+    const start = Number(match[1]);
+    const end = match[2] ? Number(match[2]) : start;
+    if (start < 1 || end > count || start > end) {
+      throw new Error(`Out of range: ${part} (choose 1-${count})`);
+    }
+    for (let n = start; n <= end; n++) picked.add(n - 1);
+  }
+  return [...picked].sort((a, b) => a - b);
+}
+
+/** Keep the items at the given indexes, in their original order. */
+export function pickSelected<T>(items: T[], indexes: number[]): T[] {
+  const wanted = new Set(indexes);
+  return items.filter((_, i) => wanted.has(i));
+}
+
+/**
+ * Let the user choose which URLs to apply to. Uses a checkbox list on a
+ * terminal and falls back to a numbered list with a typed answer otherwise.
+ */
+export async function selectJobUrls(urls: string[], interactive = Boolean(process.stdin.isTTY)): Promise<string[]> {
+  if (interactive) {
+    return checkbox({
+      message: 'Select the jobs to apply to',
+      choices: urls.map((url) => ({ name: url, value: url, checked: true })),
+      pageSize: 15,
+    });
+  }
+
+  urls.forEach((url, i) => console.log(`  ${i + 1}. ${url}`));
+  const answer = await input({
+    message: 'Jobs to apply to (e.g. 1,3-5, "all", or blank for none):',
+    validate: (value) => {
+      try {
+        parseNumericSelection(value, urls.length);
+        return true;
+      } catch (error) {
+        return error instanceof Error ? error.message : 'Invalid selection';
+      }
+    },
+  });
+  return pickSelected(urls, parseNumericSelection(answer, urls.length));
+}
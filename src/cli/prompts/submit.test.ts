@@ -0,0 +1,50 @@
+import { describe, expect, test } from 'bun:test';
+import { confirmSubmission, type SubmitPrompts } from './submit';
+
+const job = { title: 'Engineer', company: 'Acme' };
+
+function scripted(answer: boolean): SubmitPrompts & { events: string[] } {
+  const events: string[] = [];
+  return {
+    events,
+    confirm: async (message) => {
+      events.push(`confirm: ${message}`);
+      return answer;
+    },
+    tick: (remaining) => events.push(`tick ${remaining}`),
+    sleep: async (ms) => {
+      events.push(`sleep ${ms}`);
+    },
+  };
+}
+
+describe('confirmSubmission', () => {
+  test('counts down after a yes, then goes ahead', async () => {
+    const prompts = scripted(true);
+
+    expect(await confirmSubmission(job, 3, prompts)).toBe(true);
+    expect(prompts.events).toEqual([
+      'confirm: Submit your application for Engineer at Acme?',
+      'tick 3',
+      'sleep 1000',
+      'tick 2',
+      'sleep 1000',
+      'tick 1',
+      'sleep 1000',
+    ]);
+  });
+
+  test('aborts on no without counting down', async () => {
+    const prompts = scripted(false);
+
+    expect(await confirmSubmission(job, 3, prompts)).toBe(false);
+    expect(prompts.events).toEqual(['confirm: Submit your application for Engineer at Acme?']);
+  });
+
+  test('skips the countdown when it is set to zero', async () => {
+    const prompts = scripted(true);
+
+    expect(await confirmSubmission(job, 0, prompts)).toBe(true);
+    expect(prompts.events).toHaveLength(1);
+  });
+});
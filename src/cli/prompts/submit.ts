@@ -0,0 +1,38 @@
+import { confirm } from '@inquirer/prompts';
+import type { JobData } from '../../types';
+import { chalk } from '../../utils/logger';
+
+export const DEFAULT_SUBMIT_COUNTDOWN = 5;
+
+export interface SubmitPrompts {
+  confirm: (message: string) => Promise<boolean>;
+  /** Called once per second with the seconds left */
+  tick: (remaining: number) => void;
+  sleep: (ms: number) => Promise<void>;
+}
+
+const defaultPrompts: SubmitPrompts = {
+  confirm: (message) => confirm({ message, default: false }),
+  tick: (remaining) => console.log(chalk.yellow(`  Submitting in ${remaining}... (Ctrl+C to cancel)`)),
+  sleep: (ms) => Bun.sleep(ms),
+};
+
+/**
+ * Ask before a real submission (default no), then count down so there's
+ * still a moment to hit Ctrl+C. Resolves true to go ahead.
+ */
+export async function confirmSubmission(
+  jobData: Pick<JobData, 'title' | 'company'>,
+  seconds = DEFAULT_SUBMIT_COUNTDOWN,
+  prompts: SubmitPrompts = defaultPrompts
+): Promise<boolean> {
+  if (!(await prompts.confirm(`Submit your application for ${jobData.title} at ${jobData.company}?`))) {
+    return false;
+  }
+
+  for (let remaining = Math.max(0, Math.floor(seconds)); remaining > 0; remaining--) {
+    prompts.tick(remaining);
+    await prompts.sleep(1000);
+  }
+  return true;
+}
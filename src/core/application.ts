@@ -1,23 +1,36 @@
-import type { Profile, JobData, Application, GeneratedDocuments } from '../types';
+import type { Profile, JobData, Application, ApplyMethod, GeneratedDocuments } from '../types';
 import { parseJobUrl } from '../utils/url-parser';
-import { scrapeJob, createScraper } from '../scrapers';
+import { createScraper } from '../scrapers';
+import { loadJobData } from './job-cache';
 import { createAIProvider } from '../ai/provider';
 import { tailorResume } from '../ai/resume';
-import { generateCoverLetter, answerAllQuestions } from '../ai/cover-letter';
-import { evaluateJobFit, type JobFitResult } from '../ai/job-matcher';
+import { generateCoverLetter, answerAllQuestions, refineCoverLetterLoop, fitCoverLetterToLimit } from '../ai/cover-letter';
+import { evaluateJobFit, explainFit, type JobFitResult } from '../ai/job-matcher';
 export type { JobFitResult } from '../ai/job-matcher';
 import { profileRepository } from '../db/repositories/profile';
 import { applicationRepository } from '../db/repositories/application';
 import { configRepository } from '../db/repositories/config';
+import { documentRepository, type DocumentType } from '../db/repositories/document';
 import { ApplicationQueue } from './queue';
+import { fitBand, resolveFitThresholds } from './fit-bands';
 import { generateResumePdf, generateCoverLetterPdf, generateDocumentFilename } from './document';
+import { jobDocumentFilename } from './output-path';
+import { PROFILE_RESUME_SOURCE } from './base-resume';
 import { logger, createSpinner } from '../utils/logger';
+import { matchLocation } from '../utils/location';
+import { salaryDecision } from '../utils/salary';
+import { countText, formatTextLength } from '../utils/text-length';
+import { findBlockedCompany } from '../utils/company';
 import { join } from 'path';
 import { mkdir } from 'fs/promises';
 import { getAutoplyDir, ensureAutoplyDir } from '../db';
 
 export interface ApplicationResult {
   success: boolean;
+  /** Turned down on purpose (blocklist, preferences, fit threshold, review) rather than failed; error holds the reason */
+  skipped?: boolean;
+  /** Prepared and recorded as pending, but the user declined the submit confirmation */
+  notSubmitted?: boolean;
   application?: Application;
   error?: string;
   documents?: GeneratedDocuments;
@@ -29,6 +42,64 @@ export interface ApplyOptions {
   profile?: Profile;
   generateOnly?: boolean;
   autoMode?: boolean;
+  /** Print the per-factor fit breakdown */
+  explain?: boolean;
+  /** Fill the application form in the browser but don't click submit */
+  preview?: boolean;
+  /** Scrape the job page even if a recent copy is cached */
+  noCache?: boolean;
+  /** Skip jobs that aren't fully remote; defaults to preferences.remote_only */
+  remoteOnly?: boolean;
+  /** Skip jobs whose posted salary tops out below this (0 for no minimum); defaults to preferences.min_salary */
+  minSalary?: number;
+  /** Skip jobs scoring below this fit score; defaults to application.minFitScore */
+  minScore?: number;
+  /** How the user sends applications autoply doesn't submit itself (default manual) */
+  method?: ApplyMethod;
+  /** Where profile.base_resume came from, recorded on the application (default "profile") */
+  baseResumeSource?: string;
+  /** Failed application this run retries; it's updated instead of recording a new one */
+  retryApplicationId?: number;
+  /**
+   * Show the generated documents and ask before recording and submitting.
+   * Resolves true to submit; may edit review.documents in place.
+   */
+  reviewApplication?: (review: ApplicationReview) => Promise<boolean>;
+  /**
+   * Asked right before a real auto-submission that wasn't already approved in
+   * review. Resolves true to submit; false leaves the application pending.
+   */
+  confirmSubmit?: (jobData: JobData, countdownSeconds?: number) => Promise<boolean>;
+}
+
+export interface ApplicationReview {
+  jobData: JobData;
+  documents: GeneratedDocuments;
+  fitResult?: JobFitResult;
+}
+
+/** Rendered PDF paths plus the text they were made from */
+export interface GeneratedFiles {
+  resumePath?: string;
+  coverLetterPath?: string;
+  resume?: string;
+  coverLetter?: string;
+}
+
+export interface GenerateDocumentsOptions {
+  /**
+   * Called with the current cover letter after each generation; returns a
+   * refinement instruction, or an empty string to accept the letter.
+   */
+  askRefinement?: (coverLetter: string) => Promise<string>;
+  /** Scrape the job page even if a recent copy is cached */
+  noCache?: boolean;
+  /** Warn when the cover letter is longer than this many characters */
+  maxChars?: number;
+  /** With maxChars, ask the model to shorten an over-long letter instead of only warning */
+  trim?: boolean;
+  /** Name files {company}-{job}-{type}.pdf instead of after the profile, for the shared output directory */
+  jobFilenames?: boolean;
 }
 
 export class ApplicationOrchestrator {
@@ -59,8 +130,9 @@ export class ApplicationOrchestrator {
     let jobData: JobData;
     try {
       logger.debug(`Scraping ${parsedUrl.platform} job at ${url}`);
-      jobData = await scrapeJob(url, parsedUrl.platform);
-      spinner.succeed(`Scraped: ${jobData.title} at ${jobData.company}`);
+      const loaded = await loadJobData(url, parsedUrl.platform, { noCache: options.noCache });
+      jobData = loaded.jobData;
+      spinner.succeed(`${loaded.cached ? 'Cached' : 'Scraped'}: ${jobData.title} at ${jobData.company}`);
     } catch (error) {
       const msg = error instanceof Error ? error.message : 'Unknown error';
       spinner.fail(`Failed to scrape job from ${parsedUrl.platform}`);
@@ -78,27 +150,63 @@ export class ApplicationOrchestrator {
       };
     }
 
+    const blocked = findBlockedCompany(jobData.company, profile.preferences?.excluded_companies ?? []);
+    if (blocked) {
+      logger.warning(`Skipping: ${jobData.company} is on your blocklist (${blocked})`);
+      return { success: false, skipped: true, error: 'Company is on your blocklist' };
+    }
+
+    const location = matchLocation(jobData, { remoteOnly: options.remoteOnly ?? profile.preferences?.remote_only });
+    if (!location.ok) {
+      logger.info(`Skipping: ${jobData.title} is not remote (${location.where})`);
+      return { success: false, skipped: true, error: `Not remote: ${jobData.title} at ${jobData.company} (${location.where})` };
+    }
+
+    const minSalary = options.minSalary ?? profile.preferences?.min_salary;
+    if (minSalary) {
+      const decision = salaryDecision(jobData.salary, minSalary);
+      if (decision === 'below') {
+        logger.warning(`Skipping: ${jobData.title} pays ${jobData.salary}, below your minimum of ${minSalary.toLocaleString()}`);
+        return { success: false, skipped: true, error: 'Salary below minimum' };
+      }
+      if (decision === 'unknown') {
+        logger.info('  (salary unknown)');
+      }
+    }
+
     // Evaluate job fit
     let fitResult: JobFitResult | undefined;
     try {
       const provider = createAIProvider();
       if (await provider.isAvailable()) {
         spinner.start('Evaluating job fit...');
-        fitResult = await evaluateJobFit(provider, profile, jobData);
-        spinner.succeed(`Fit score: ${fitResult.score}% (${fitResult.recommendation})`);
+        const config = configRepository.loadAppConfig();
+        fitResult = await evaluateJobFit(provider, profile, jobData, {
+          neutralScore: config.application.neutralFitScore,
+        });
+        const thresholds = resolveFitThresholds(config.application, options.minScore);
+        spinner.succeed(
+          `Fit score: ${fitResult.score}% (${fitResult.recommendation}, ${fitBand(fitResult.score, thresholds)} match)`
+        );
 
-        if (fitResult.strongMatches.length > 0) {
-          logger.info(`  Strong: ${fitResult.strongMatches.slice(0, 3).join(', ')}`);
-        }
-        if (fitResult.missingSkills.length > 0) {
-          logger.info(`  Gaps: ${fitResult.missingSkills.slice(0, 3).join(', ')}`);
+        if (options.explain) {
+          for (const line of explainFit(fitResult)) {
+            logger.info(`  ${line}`);
+          }
+        } else {
+          if (fitResult.strongMatches.length > 0) {
+            logger.info(`  Strong: ${fitResult.strongMatches.slice(0, 3).join(', ')}`);
+          }
+          if (fitResult.missingSkills.length > 0) {
+            logger.info(`  Gaps: ${fitResult.missingSkills.slice(0, 3).join(', ')}`);
+          }
         }
 
         // Check minimum fit score threshold
-        const config = configRepository.loadAppConfig();
-        if (config.application.minFitScore && fitResult.score < config.application.minFitScore) {
-          logger.warning(`Skipping: fit score ${fitResult.score}% below threshold ${config.application.minFitScore}%`);
-          return { success: false, error: `Fit score below threshold`, fitResult };
+        const minScore = options.minScore ?? config.application.minFitScore;
+        if (minScore !== undefined && fitResult.score < minScore) {
+          logger.warning(`Skipping: fit score ${fitResult.score}% below threshold ${minScore}%`);
+          return { success: false, skipped: true, error: 'Fit score below threshold', fitResult };
         }
       }
     } catch {
@@ -110,7 +218,7 @@ export class ApplicationOrchestrator {
     spinner.start('Generating tailored resume...');
     let documents: GeneratedDocuments;
     try {
-      const provider = createAIProvider();
+      const provider = createAIProvider(undefined, 'resume');
       const isAvailable = await provider.isAvailable();
       if (!isAvailable) {
         spinner.fail('AI provider not available');
@@ -121,7 +229,7 @@ export class ApplicationOrchestrator {
       spinner.succeed('Resume generated');
 
       spinner.start('Generating cover letter...');
-      const coverLetter = await generateCoverLetter(provider, profile, jobData);
+      const coverLetter = await generateCoverLetter(createAIProvider(undefined, 'cover-letter'), profile, jobData);
       spinner.succeed('Cover letter generated');
 
       documents = { resume, coverLetter };
@@ -147,15 +255,17 @@ export class ApplicationOrchestrator {
       }
 
       // Create application record
-      const application = applicationRepository.create({
+      const application = applicationRepository.createOrRetry(options.retryApplicationId, {
         profile_id: profile.id!,
         url,
         platform: parsedUrl.platform,
         company: jobData.company,
         job_title: jobData.title,
         status: dryRun ? 'pending' : 'submitted',
+        apply_method: options.method ?? 'manual',
         generated_resume: documents.resume,
         generated_cover_letter: documents.coverLetter,
+        base_resume_source: options.baseResumeSource ?? PROFILE_RESUME_SOURCE,
       });
 
       return { success: true, application, documents, fitResult };
@@ -204,16 +314,30 @@ export class ApplicationOrchestrator {
       }
     }
 
+    // Let the user review before anything is recorded or sent
+    let reviewed = false;
+    if (options.reviewApplication) {
+      const review: ApplicationReview = { jobData, documents, fitResult };
+      if (!(await options.reviewApplication(review))) {
+        logger.info('Skipped. Nothing was recorded.');
+        return { success: false, skipped: true, error: 'Skipped after review', documents, fitResult };
+      }
+      documents = review.documents;
+      reviewed = true;
+    }
+
     // Create application record
-    const application = applicationRepository.create({
+    const application = applicationRepository.createOrRetry(options.retryApplicationId, {
       profile_id: profile.id!,
       url,
       platform: parsedUrl.platform,
       company: jobData.company,
       job_title: jobData.title,
       status: 'pending',
+      apply_method: options.method ?? 'manual',
       generated_resume: documents.resume,
       generated_cover_letter: documents.coverLetter,
+      base_resume_source: options.baseResumeSource ?? PROFILE_RESUME_SOURCE,
       form_data: {
         fields: jobData.form_fields,
         questions: jobData.custom_questions,
@@ -222,13 +346,43 @@ export class ApplicationOrchestrator {
 
     // Check if auto-submit is enabled
     const config = configRepository.loadAppConfig();
-    if (config.application.autoSubmit) {
+    if (options.preview) {
+      logger.debug(`Filling application on ${parsedUrl.platform} at ${url} (preview)`);
+      spinner.start('Filling application form (preview, will not submit)...');
+      try {
+        await this.submitApplication(application, jobData, profile, documents, { preview: true });
+        spinner.succeed('Form filled, not submitted');
+        logger.info(`Application #${application.id} left as pending.`);
+      } catch (error) {
+        const msg = error instanceof Error ? error.message : 'Unknown error';
+        applicationRepository.update(application.id!, { status: 'failed', error_message: msg });
+        spinner.fail(`Could not fill the form on ${parsedUrl.platform}`);
+        return { success: false, application, error: msg, documents, fitResult };
+      }
+    } else if (
+      config.application.autoSubmit &&
+      // Confirming in review already answered this
+      !reviewed &&
+      options.confirmSubmit &&
+      !(await options.confirmSubmit(jobData, config.application.submitCountdown))
+    ) {
+      logger.info(`Not submitted. Application #${application.id} left as pending.`);
+      return {
+        success: false,
+        notSubmitted: true,
+        application,
+        error: `Not submitted: ${jobData.title} at ${jobData.company} (application #${application.id} is pending)`,
+        documents,
+        fitResult,
+      };
+    } else if (config.application.autoSubmit) {
       logger.debug(`Submitting application to ${parsedUrl.platform} at ${url}`);
       spinner.start('Submitting application...');
       try {
         await this.submitApplication(application, jobData, profile, documents);
         applicationRepository.update(application.id!, {
           status: 'submitted',
+          apply_method: 'auto',
           applied_at: new Date().toISOString(),
         });
         spinner.succeed('Application submitted!');
@@ -247,6 +401,14 @@ export class ApplicationOrchestrator {
           fitResult,
         };
       }
+    } else if (reviewed) {
+      // Auto-submit is off, so confirming only records that the user is applying themselves
+      applicationRepository.update(application.id!, {
+        status: 'submitted',
+        apply_method: options.method ?? 'manual',
+        applied_at: new Date().toISOString(),
+      });
+      logger.info(`Application #${application.id} recorded. Auto-submit is off, so submit the form yourself.`);
     } else {
       logger.info('Auto-submit disabled. Application prepared but not submitted.');
       logger.info('Set autoSubmit to true in config to enable automatic submission.');
@@ -259,7 +421,8 @@ export class ApplicationOrchestrator {
     application: Application,
     jobData: JobData,
     profile: Profile,
-    documents: GeneratedDocuments
+    documents: GeneratedDocuments,
+    submitOptions: { preview?: boolean } = {}
   ): Promise<void> {
     const config = configRepository.loadAppConfig();
 
@@ -299,6 +462,7 @@ export class ApplicationOrchestrator {
       resumePath: resumePdfPath,
       coverLetterPath: coverLetterPdfPath,
       answeredQuestions,
+      preview: submitOptions.preview,
     });
 
     if (!result.success) {
@@ -324,6 +488,8 @@ export class ApplicationOrchestrator {
 
       if (result.success) {
         logger.success(`Completed: ${result.application?.job_title} at ${result.application?.company}`);
+      } else if (result.skipped || result.notSubmitted) {
+        logger.info(result.skipped ? `Skipped: ${result.error}` : result.error!);
       } else {
         logger.error(`Failed: ${result.error}`);
       }
@@ -337,8 +503,9 @@ export class ApplicationOrchestrator {
   async generateDocuments(
     url: string,
     outputDir: string,
-    type: 'resume' | 'cover-letter' | 'both' = 'both'
-  ): Promise<{ resumePath?: string; coverLetterPath?: string }> {
+    type: 'resume' | 'cover-letter' | 'both' = 'both',
+    options: GenerateDocumentsOptions = {}
+  ): Promise<GeneratedFiles> {
     const parsedUrl = parseJobUrl(url);
     if (!parsedUrl.isValid) {
       throw new Error(parsedUrl.error);
@@ -352,28 +519,74 @@ export class ApplicationOrchestrator {
     const spinner = createSpinner('Scraping job...');
     spinner.start();
 
-    const jobData = await scrapeJob(url, parsedUrl.platform);
-    spinner.succeed(`Scraped: ${jobData.title} at ${jobData.company}`);
+    const { jobData, cached } = await loadJobData(url, parsedUrl.platform, { noCache: options.noCache });
+    spinner.succeed(`${cached ? 'Cached' : 'Scraped'}: ${jobData.title} at ${jobData.company}`);
 
-    const provider = createAIProvider();
-    const result: { resumePath?: string; coverLetterPath?: string } = {};
+    return this.generateDocumentsForJob(profile, jobData, outputDir, type, options);
+  }
+
+  /** Tailor and render documents for an already-scraped job. */
+  async generateDocumentsForJob(
+    profile: Profile,
+    jobData: JobData,
+    outputDir: string,
+    type: 'resume' | 'cover-letter' | 'both' = 'both',
+    options: GenerateDocumentsOptions = {}
+  ): Promise<GeneratedFiles> {
+    const spinner = createSpinner('Generating documents...');
+    const result: GeneratedFiles = {};
+    const saveToDb = configRepository.loadAppConfig().application.saveGeneratedDocuments !== false;
+    const remember = (type: DocumentType, content: string) => {
+      if (!saveToDb) return;
+      documentRepository.save({ url: jobData.url, company: jobData.company, job_title: jobData.title, type, content });
+    };
 
     if (type === 'resume' || type === 'both') {
       spinner.start('Generating tailored resume...');
-      const resume = await tailorResume(provider, profile, jobData);
-      const resumePath = join(outputDir, generateDocumentFilename(profile.name, 'resume'));
+      const resume = await tailorResume(createAIProvider(undefined, 'resume'), profile, jobData);
+      const resumePath = join(
+        outputDir,
+        options.jobFilenames ? jobDocumentFilename(jobData, 'resume') : generateDocumentFilename(profile.name, 'resume')
+      );
       await generateResumePdf(resume, resumePath, profile.name);
+      remember('resume', resume);
+      result.resume = resume;
       result.resumePath = resumePath;
       spinner.succeed(`Resume saved to: ${resumePath}`);
     }
 
     if (type === 'cover-letter' || type === 'both') {
       spinner.start('Generating cover letter...');
-      const coverLetter = await generateCoverLetter(provider, profile, jobData);
-      const coverPath = join(outputDir, generateDocumentFilename(profile.name, 'cover_letter'));
+      const provider = createAIProvider(undefined, 'cover-letter');
+      let coverLetter = await generateCoverLetter(provider, profile, jobData);
+      if (options.askRefinement) {
+        spinner.stop();
+        coverLetter = await refineCoverLetterLoop(provider, coverLetter, options.askRefinement);
+        spinner.start('Saving cover letter...');
+      }
+      let length = countText(coverLetter);
+      if (options.maxChars !== undefined && length.chars > options.maxChars) {
+        if (options.trim) spinner.text = `Trimming cover letter to ${options.maxChars} characters...`;
+        const checked = await fitCoverLetterToLimit(provider, coverLetter, options.maxChars, { trim: options.trim });
+        coverLetter = checked.letter;
+        length = checked.length;
+        if (checked.overLimit) {
+          spinner.stop();
+          logger.warning(
+            `Cover letter is ${length.chars} characters, over the ${options.maxChars} limit${options.trim ? ' even after trimming' : ' (add --trim to shorten it)'}`
+          );
+          spinner.start('Saving cover letter...');
+        }
+      }
+      const coverPath = join(
+        outputDir,
+        options.jobFilenames ? jobDocumentFilename(jobData, 'cover-letter') : generateDocumentFilename(profile.name, 'cover_letter')
+      );
       await generateCoverLetterPdf(coverLetter, coverPath, profile.name);
+      remember('cover-letter', coverLetter);
+      result.coverLetter = coverLetter;
       result.coverLetterPath = coverPath;
-      spinner.succeed(`Cover letter saved to: ${coverPath}`);
+      spinner.succeed(`Cover letter saved to: ${coverPath} (${formatTextLength(length)})`);
     }
 
     return result;
@@ -0,0 +1,137 @@
+import { describe, expect, test } from 'bun:test';
+import { createTestProfile, useTempDataDir } from '../db/test-helpers';
+import { profileRepository } from '../db/repositories/profile';
+import { applicationRepository } from '../db/repositories/application';
+import { configRepository } from '../db/repositories/config';
+import { documentRepository } from '../db/repositories/document';
+import { ARCHIVE_VERSION, buildArchive, parseArchive, restoreArchive } from './archive';
+
+const useFreshDataDir = useTempDataDir();
+
+function seed(): void {
+  const profile = createTestProfile({
+    preferences: { remote_only: true, job_types: ['full-time'], preferred_locations: [], excluded_companies: [] },
+    skills: ['TypeScript'],
+  });
+  applicationRepository.create({
+    profile_id: profile.id!,
+    url: 'https://jobs.lever.co/acme/1',
+    platform: 'lever',
+    company: 'Acme',
+    job_title: 'Engineer',
+    status: 'submitted',
+    generated_cover_letter: 'Dear Acme',
+    form_data: { email: 'ada@example.com' },
+    applied_at: '2026-01-02 10:00:00',
+    created_at: '2026-01-01 09:00:00',
+  });
+  const globex = applicationRepository.create({
+    profile_id: profile.id!,
+    url: 'https://boards.greenhouse.io/globex/jobs/2',
+    platform: 'greenhouse',
+    company: 'Globex',
+    job_title: 'Staff Engineer',
+    status: 'failed',
+    error_message: 'Timeout',
+  });
+  applicationRepository.addTags(globex.id!, ['dream', 'referral']);
+  documentRepository.save({
+    url: 'https://jobs.lever.co/acme/1',
+    company: 'Acme',
+    job_title: 'Engineer',
+    type: 'cover-letter',
+    content: 'Dear Acme, hello there',
+  });
+  configRepository.set('last_run', '2026-01-03');
+}
+
+describe('archive round-trip', () => {
+  test('restores the same records into a fresh database', () => {
+    seed();
+    const exported = JSON.stringify(buildArchive());
+
+    useFreshDataDir();
+    // Push the new ids away from the old ones so remapping is exercised
+    profileRepository.delete(createTestProfile().id!);
+
+    const summary = restoreArchive(parseArchive(exported));
+
+    expect(summary).toEqual({ profiles: 1, applications: 2, documents: 1, settings: 1 });
+    expect(profileRepository.findAll()).toHaveLength(1);
+    expect(applicationRepository.count()).toBe(2);
+
+    const profile = profileRepository.findFirst()!;
+    const apps = applicationRepository.findAll();
+    expect(apps.every((app) => app.profile_id === profile.id)).toBe(true);
+
+    const lever = apps.find((app) => app.platform === 'lever')!;
+    expect(lever.created_at).toBe('2026-01-01 09:00:00');
+    expect(lever.form_data).toEqual({ email: 'ada@example.com' });
+    expect(configRepository.get('last_run')).toBe('2026-01-03');
+  });
+
+  test('keeps tags on the restored applications', () => {
+    seed();
+    const exported = JSON.stringify(buildArchive());
+
+    useFreshDataDir();
+    restoreArchive(parseArchive(exported));
+
+    const [globex] = applicationRepository.findAll({ tag: 'dream' });
+    expect(globex.company).toBe('Globex');
+    expect(applicationRepository.getTags(globex.id!)).toEqual(['dream', 'referral']);
+    expect(applicationRepository.countTags()).toHaveLength(2);
+  });
+
+  test('restores generated documents with their dates and counts', () => {
+    seed();
+    const original = documentRepository.findAll()[0];
+    const exported = JSON.stringify(buildArchive());
+
+    useFreshDataDir();
+    restoreArchive(parseArchive(exported));
+
+    const restored = documentRepository.findLatest('https://jobs.lever.co/acme/1', 'cover-letter');
+    expect(restored).toMatchObject({
+      content: 'Dear Acme, hello there',
+      word_count: 4,
+      char_count: original.char_count,
+      created_at: original.created_at,
+    });
+  });
+
+  test('imports version 1 archives, which have no tags', () => {
+    seed();
+    const archive = buildArchive();
+    const v1 = { ...archive, version: 1, applications: archive.applications.map(({ tags: _tags, ...app }) => app) };
+
+    useFreshDataDir();
+    expect(restoreArchive(parseArchive(JSON.stringify(v1))).applications).toBe(2);
+    expect(applicationRepository.countTags()).toEqual([]);
+  });
+
+  test('refuses to import into a database that already has data', () => {
+    seed();
+    const archive = buildArchive();
+
+    expect(() => restoreArchive(archive)).toThrow('not empty');
+  });
+});
+
+describe('parseArchive', () => {
+  test('treats missing sections as empty', () => {
+    const archive = parseArchive(JSON.stringify({ version: 1, profiles: [] }));
+    expect(archive.applications).toEqual([]);
+    expect(archive.documents).toEqual([]);
+    expect(archive.settings).toEqual({});
+  });
+
+  test('rejects archives from a newer version', () => {
+    expect(() => parseArchive(JSON.stringify({ version: ARCHIVE_VERSION + 1 }))).toThrow('newer');
+  });
+
+  test('rejects invalid JSON and missing versions', () => {
+    expect(() => parseArchive('nope')).toThrow('not valid JSON');
+    expect(() => parseArchive('{}')).toThrow('version');
+  });
+});
@@ -0,0 +1,122 @@
+import type { Application, Profile } from '../types';
+import { getDb } from '../db';
+import { profileRepository } from '../db/repositories/profile';
+import { applicationRepository } from '../db/repositories/application';
+import { configRepository } from '../db/repositories/config';
+import { documentRepository, type SavedDocument } from '../db/repositories/document';
+
+/** Bump when the archive layout changes in a way older readers can't handle. */
+export const ARCHIVE_VERSION = 2;
+
+/** An application with its tags (version 2+) */
+export type ArchivedApplication = Application & { tags?: string[] };
+
+export interface Archive {
+  version: number;
+  exported_at: string;
+  profiles: Profile[];
+  applications: ArchivedApplication[];
+  /** Generated resumes and cover letters kept for "generate get" */
+  documents: SavedDocument[];
+  /** Key/value settings stored in the database (config.json is not included) */
+  settings: Record<string, string>;
+}
+
+export interface RestoreSummary {
+  profiles: number;
+  applications: number;
+  documents: number;
+  settings: number;
+}
+
+export function buildArchive(now = new Date()): Archive {
+  return {
+    version: ARCHIVE_VERSION,
+    exported_at: now.toISOString(),
+    profiles: profileRepository.findAll(),
+    applications: applicationRepository.findAll().map((app) => ({ ...app, tags: applicationRepository.getTags(app.id!) })),
+    documents: documentRepository.findAll(),
+    settings: configRepository.getAll(),
+  };
+}
+
+/**
+ * Validate an archive read from disk. Unknown fields are ignored and missing
+ * sections are treated as empty so older exports keep importing.
+ */
+export function parseArchive(content: string): Archive {
+  let raw: unknown;
+  try {
+    raw = JSON.parse(content);
+  } catch {
+    throw new Error('Archive is not valid JSON');
+  }
+  if (!raw || typeof raw !== 'object' || Array.isArray(raw)) {
+    throw new Error('Archive must be a JSON object');
+  }
+
+  const data = raw as Partial<Archive>;
+  if (typeof data.version !== 'number') {
+    throw new Error('Archive is missing its version field');
+  }
+  if (data.version > ARCHIVE_VERSION) {
+    throw new Error(`Archive version ${data.version} is newer than this autoply supports (${ARCHIVE_VERSION})`);
+  }
+
+  return {
+    version: data.version,
+    exported_at: typeof data.exported_at === 'string' ? data.exported_at : '',
+    profiles: Array.isArray(data.profiles) ? data.profiles : [],
+    applications: Array.isArray(data.applications) ? data.applications : [],
+    documents: Array.isArray(data.documents) ? data.documents : [],
+    settings: data.settings && typeof data.settings === 'object' ? data.settings : {},
+  };
+}
+
+/**
+ * Load an archive into an empty database. Profile ids are reassigned and
+ * applications are pointed at the new ids; everything runs in one transaction.
+ */
+export function restoreArchive(archive: Archive): RestoreSummary {
+  if (profileRepository.findAll().length > 0 || applicationRepository.count() > 0) {
+    throw new Error('Database is not empty. Import into a fresh data directory (--data-dir) instead.');
+  }
+
+  const restore = getDb().transaction(() => {
+    const profileIds = new Map<number, number>();
+    for (const { id, ...profile } of archive.profiles) {
+      const created = profileRepository.create(profile);
+      if (id !== undefined) profileIds.set(id, created.id!);
+    }
+
+    let applications = 0;
+    for (const { id: _id, tags, ...application } of archive.applications) {
+      const profileId = profileIds.get(application.profile_id);
+      if (profileId === undefined) {
+        throw new Error(`Application for ${application.url} references unknown profile ${application.profile_id}`);
+      }
+      const created = applicationRepository.create({ ...application, profile_id: profileId });
+      if (Array.isArray(tags) && tags.length > 0) {
+        applicationRepository.addTags(created.id!, tags);
+      }
+      applications++;
+    }
+
+    for (const { id: _id, ...document } of archive.documents) {
+      documentRepository.restore(document);
+    }
+
+    for (const [key, value] of Object.entries(archive.settings)) {
+      configRepository.set(key, String(value));
+    }
+
+    return {
+      profiles: archive.profiles.length,
+      applications,
+      documents: archive.documents.length,
+      settings: Object.keys(archive.settings).length,
+    };
+  });
+
+  return restore();
+}
@@ -0,0 +1,41 @@
+import { describe, expect, test } from 'bun:test';
+import { chooseBaseResume, PROFILE_RESUME_SOURCE } from './base-resume';
+import type { Profile } from '../types';
+
+const profile: Profile = {
+  id: 1,
+  name: 'Ada Lovelace',
+  email: 'ada@example.com',
+  base_resume: 'Saved resume',
+  skills: [],
+  experience: [],
+  education: [],
+};
+
+describe('chooseBaseResume', () => {
+  test('uses the saved resume when no file is given', async () => {
+    const extract = async () => {
+      throw new Error('should not read a file');
+    };
+
+    expect(await chooseBaseResume(profile, undefined, extract)).toEqual({ profile, source: PROFILE_RESUME_SOURCE });
+  });
+
+  test('swaps in the file and records its path', async () => {
+    const choice = await chooseBaseResume(profile, 'backend.md', async () => ({
+      success: true,
+      content: 'Backend resume',
+      filePath: '/home/ada/backend.md',
+    }));
+
+    expect(choice.profile.base_resume).toBe('Backend resume');
+    expect(choice.source).toBe('/home/ada/backend.md');
+    expect(profile.base_resume).toBe('Saved resume');
+  });
+
+  test('throws when the file cannot be read', async () => {
+    await expect(chooseBaseResume(profile, 'missing.pdf', async () => ({ success: false, error: 'File not found' }))).rejects.toThrow(
+      'File not found'
+    );
+  });
+});
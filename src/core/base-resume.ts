@@ -0,0 +1,32 @@
+import type { Profile } from '../types';
+import { extractTextFromFile, type ExtractionResult } from '../utils/document-extractor';
+
+/** Recorded on applications tailored from the resume saved in the profile */
+export const PROFILE_RESUME_SOURCE = 'profile';
+
+export interface BaseResumeChoice {
+  profile: Profile;
+  /** "profile", or the absolute path of the --base-resume file */
+  source: string;
+}
+
+/**
+ * The profile to tailor from for this run. With a path, its text replaces
+ * base_resume in memory only; the saved profile is untouched.
+ */
+export async function chooseBaseResume(
+  profile: Profile,
+  path?: string,
+  extract: (path: string) => Promise<ExtractionResult> = extractTextFromFile
+): Promise<BaseResumeChoice> {
+  if (!path) return { profile, source: PROFILE_RESUME_SOURCE };
+
+  const extracted = await extract(path);
+  if (!extracted.success) {
+    throw new Error(extracted.error ?? `Could not read ${path}`);
+  }
+  return {
+    profile: { ...profile, base_resume: extracted.content },
+    source: extracted.filePath ?? path,
+  };
+}
@@ -0,0 +1,97 @@
+import { describe, expect, test, afterEach } from 'bun:test';
+import { existsSync, mkdtempSync, readdirSync, rmSync, writeFileSync } from 'fs';
+import { tmpdir } from 'os';
+import { join } from 'path';
+import { generatePackages, packageDirName } from './batch-generate';
+import type { JobData } from '../types';
+
+function job(company: string, title: string, url: string): JobData {
+  return {
+    url,
+    platform: 'generic',
+    title,
+    company,
+    description: '',
+    requirements: [],
+    qualifications: [],
+    form_fields: [],
+    custom_questions: [],
+  };
+}
+
+let tempDir: string | undefined;
+
+afterEach(() => {
+  if (tempDir) rmSync(tempDir, { recursive: true, force: true });
+  tempDir = undefined;
+});
+
+describe('packageDirName', () => {
+  test('combines the company with the job id from the URL', () => {
+    expect(packageDirName({ company: 'Acme, Inc.', title: 'Engineer' }, 'https://boards.greenhouse.io/acme/jobs/4012345')).toBe('acme-inc-4012345');
+    expect(
+      packageDirName({ company: 'Globex', title: 'Engineer' }, 'https://jobs.lever.co/globex/5b1c2d3e-aaaa-bbbb-cccc-1234567890ab')
+    ).toBe('globex-5b1c2d3e-aaaa-bbbb-cccc-1234567890ab');
+  });
+
+  test('falls back to the title when the URL has no id', () => {
+    expect(packageDirName({ company: 'Initech', title: 'Senior Engineer' }, 'https://initech.com/careers/backend')).toBe(
+      'initech-senior-engineer'
+    );
+  });
+});
+
+describe('generatePackages', () => {
+  test('writes one folder per job with both documents', async () => {
+    tempDir = mkdtempSync(join(tmpdir(), 'autoply-batch-'));
+    const jobs: Record<string, JobData> = {
+      'https://boards.greenhouse.io/acme/jobs/1': job('Acme', 'Engineer', 'https://boards.greenhouse.io/acme/jobs/1'),
+      'https://boards.greenhouse.io/globex/jobs/2': job('Globex', 'Designer', 'https://boards.greenhouse.io/globex/jobs/2'),
+    };
+    const progress: number[] = [];
+
+    const results = await generatePackages(Object.keys(jobs), tempDir, {
+      loadJob: async (url) => jobs[url],
+      generate: async (_jobData, dir) => {
+        const resumePath = join(dir, 'resume.pdf');
+        const coverLetterPath = join(dir, 'cover_letter.pdf');
+        writeFileSync(resumePath, 'resume');
+        writeFileSync(coverLetterPath, 'letter');
+        return { resumePath, coverLetterPath };
+      },
+      onProgress: (index) => progress.push(index),
+    });
+
+    expect(results.every((r) => r.success)).toBe(true);
+    expect(readdirSync(tempDir).sort()).toEqual(['acme-1', 'globex-2']);
+    expect(existsSync(join(tempDir, 'acme-1', 'resume.pdf'))).toBe(true);
+    expect(existsSync(join(tempDir, 'globex-2', 'cover_letter.pdf'))).toBe(true);
+    expect(progress).toEqual([1, 2]);
+  });
+
+  test('records failures and keeps going', async () => {
+    tempDir = mkdtempSync(join(tmpdir(), 'autoply-batch-'));
+
+    const results = await generatePackages(['https://a.com/jobs/1', 'https://b.com/jobs/2'], tempDir, {
+      loadJob: async (url) => {
+        if (url.includes('a.com')) throw new Error('Scrape failed');
+        return job('B', 'Engineer', url);
+      },
+      generate: async () => ({}),
+    });
+
+    expect(results.map((r) => r.success)).toEqual([false, true]);
+    expect(results[0].error).toBe('Scrape failed');
+  });
+
+  test('keeps folder names unique', async () => {
+    tempDir = mkdtempSync(join(tmpdir(), 'autoply-batch-'));
+
+    await generatePackages(['https://acme.com/careers/a', 'https://acme.com/careers/b'], tempDir, {
+      loadJob: async (url) => job('Acme', 'Engineer', url),
+      generate: async () => ({}),
+    });
+
+    expect(readdirSync(tempDir).sort()).toEqual(['acme-engineer', 'acme-engineer-2']);
+  });
+});
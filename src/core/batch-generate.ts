@@ -0,0 +1,78 @@
+import { mkdir } from 'fs/promises';
+import { join } from 'path';
+import type { JobData } from '../types';
+
+export interface PackageResult {
+  url: string;
+  success: boolean;
+  dir?: string;
+  resumePath?: string;
+  coverLetterPath?: string;
+  error?: string;
+}
+
+export interface PackageDeps {
+  loadJob: (url: string) => Promise<JobData>;
+  generate: (jobData: JobData, dir: string) => Promise<{ resumePath?: string; coverLetterPath?: string }>;
+  /** Called before each job starts, with a 1-based index */
+  onProgress?: (index: number, total: number, url: string) => void;
+}
+
+function slugify(value: string): string {
+  return value
+    .toLowerCase()
+    .normalize('NFKD')
+    .replace(/[\u0300-\u036f]/g, '')
+    .replace(/[^a-z0-9]+/g, '-')
+    .replace(/^-+|-+$/g, '')
+    .slice(0, 40)
+    .replace(/-+$/, '');
+}
+
+/**
+ * Folder name for a job's documents: company plus the posting's id from the
+ * URL (or the title when the URL has none), e.g. "acme-4012345".
+ */
+export function packageDirName(jobData: Pick<JobData, 'company' | 'title'>, url: string): string {
+  let jobId = '';
+  try {
+    const segments = new URL(url).pathname.split('/').filter(Boolean);
+    jobId = [...segments].reverse().find((segment) => /\d/.test(segment)) ?? '';
+  } catch {
+    // Fall back to the title below
+  }
+  const company = slugify(jobData.company) || 'company';
+  const job = slugify(jobId) || slugify(jobData.title) || 'job';
+  return `${company}-${job}`;
+}
+
+/**
+ * Generate a resume and cover letter per URL into baseDir/{company}-{job}/.
+ * A failing job is recorded and skipped so one bad URL doesn't stop the batch.
+ */
+export async function generatePackages(urls: string[], baseDir: string, deps: PackageDeps): Promise<PackageResult[]> {
+  const results: PackageResult[] = [];
+  const usedDirs = new Set<string>();
+
+  for (const [index, url] of urls.entries()) {
+    deps.onProgress?.(index + 1, urls.length, url);
+    try {
+      const jobData = await deps.loadJob(url);
+
+      let name = packageDirName(jobData, url);
+      for (let n = 2; usedDirs.has(name); n++) {
+        name = `${packageDirName(jobData, url)}-${n}`;
+      }
+      usedDirs.add(name);
+
+      const dir = join(baseDir, name);
+      await mkdir(dir, { recursive: true });
+      const paths = await deps.generate(jobData, dir);
+      results.push({ url, success: true, dir, ...paths });
+    } catch (error) {
+      results.push({ url, success: false, error: error instanceof Error ? error.message : String(error) });
+    }
+  }
+
+  return results;
+}
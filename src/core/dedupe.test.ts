@@ -0,0 +1,100 @@
+import { describe, expect, test } from 'bun:test';
+import { findDuplicateApplications } from './dedupe';
+import type { Application } from '../types';
+
+let nextId = 1;
+
+function app(overrides: Partial<Application>): Application {
+  return {
+    id: nextId++,
+    profile_id: 1,
+    url: 'https://boards.greenhouse.io/acme/jobs/1',
+    platform: 'greenhouse',
+    company: 'Acme',
+    job_title: 'Engineer',
+    status: 'pending',
+    ...overrides,
+  };
+}
+
+describe('findDuplicateApplications', () => {
+  test('groups URLs that only differ by tracking params and trailing slash', () => {
+    const a = app({ url: 'https://boards.greenhouse.io/acme/jobs/1' });
+    const b = app({ url: 'https://boards.greenhouse.io/acme/jobs/1/?utm_source=linkedin' });
+    const c = app({ url: 'https://boards.greenhouse.io/acme/jobs/2' });
+
+    const groups = findDuplicateApplications([a, b, c]);
+
+    expect(groups).toHaveLength(1);
+    expect(groups[0].survivor.id).toBe(a.id);
+    expect(groups[0].duplicates.map((d) => d.id)).toEqual([b.id]);
+  });
+
+  test('keeps the submitted record over failed and pending ones', () => {
+    const failed = app({ status: 'failed', generated_resume: 'long resume text' });
+    const submitted = app({ status: 'submitted', url: 'https://boards.greenhouse.io/acme/jobs/1#apply' });
+
+    const [group] = findDuplicateApplications([failed, submitted]);
+
+    expect(group.survivor.id).toBe(submitted.id);
+  });
+
+  test('prefers the most complete record when statuses match', () => {
+    const bare = app({});
+    const full = app({ generated_resume: 'resume', generated_cover_letter: 'letter' });
+
+    const [group] = findDuplicateApplications([bare, full]);
+
+    expect(group.survivor.id).toBe(full.id);
+  });
+
+  test('carries over documents the survivor is missing', () => {
+    const submitted = app({ status: 'submitted', generated_resume: 'resume' });
+    const draft = app({ generated_cover_letter: 'letter', form_data: { email: 'a@b.c' } });
+
+    const [group] = findDuplicateApplications([submitted, draft]);
+
+    expect(group.merged).toEqual({ generated_cover_letter: 'letter', form_data: { email: 'a@b.c' } });
+  });
+
+  test('carries over the apply method and keeps notes from every record', () => {
+    const submitted = app({ status: 'submitted', notes: 'Recruiter call booked' });
+    const draft = app({ apply_method: 'email', notes: 'Referred by Sam' });
+    const retry = app({ status: 'failed', notes: 'Recruiter call booked' });
+
+    const [group] = findDuplicateApplications([submitted, draft, retry]);
+
+    expect(group.merged).toEqual({ apply_method: 'email', notes: 'Recruiter call booked\nReferred by Sam' });
+  });
+
+  test('leaves notes alone when duplicates add nothing', () => {
+    const submitted = app({ status: 'submitted', notes: 'Keep me' });
+    const draft = app({});
+
+    const [group] = findDuplicateApplications([submitted, draft]);
+
+    expect(group.merged).toEqual({});
+  });
+
+  test('keeps a forced re-application alongside the first submission', () => {
+    const first = app({ status: 'submitted', applied_at: '2026-01-01T10:00:00Z' });
+    const reapplied = app({ status: 'submitted', applied_at: '2026-02-01T10:00:00Z' });
+
+    expect(findDuplicateApplications([first, reapplied])).toEqual([]);
+  });
+
+  test('only removes pending and failed records when several were submitted', () => {
+    const first = app({ status: 'submitted', generated_resume: 'resume' });
+    const reapplied = app({ status: 'submitted' });
+    const failed = app({ status: 'failed' });
+
+    const [group] = findDuplicateApplications([first, reapplied, failed]);
+
+    expect(group.survivor.id).toBe(first.id);
+    expect(group.duplicates.map((d) => d.id)).toEqual([failed.id]);
+  });
+
+  test('does not merge across profiles', () => {
+    expect(findDuplicateApplications([app({ profile_id: 1 }), app({ profile_id: 2 })])).toEqual([]);
+  });
+});
@@ -0,0 +1,100 @@
+import type { Application, ApplicationStatus } from '../types';
+import { normalizeUrl } from '../utils/url-parser';
+
+export interface DuplicateGroup {
+  key: string;
+  survivor: Application;
+  duplicates: Application[];
+  /** Fields the survivor is missing that a duplicate can fill in */
+  merged: Partial<Application>;
+}
+
+const STATUS_RANK: Record<ApplicationStatus, number> = {
+  submitted: 2,
+  pending: 1,
+  failed: 0,
+};
+
+function completeness(app: Application): number {
+  return (
+    (app.generated_resume?.length ?? 0) +
+    (app.generated_cover_letter?.length ?? 0) +
+    (app.form_data ? Object.keys(app.form_data).length : 0) +
+    (app.applied_at ? 1 : 0)
+  );
+}
+
+/**
+ * Prefer a submitted record over pending/failed ones, then the one with the
+ * most generated data, then the oldest.
+ */
+function compareSurvivor(a: Application, b: Application): number {
+  return (
+    STATUS_RANK[b.status] - STATUS_RANK[a.status] ||
+    completeness(b) - completeness(a) ||
+    (a.id ?? 0) - (b.id ?? 0)
+  );
+}
+
+function mergeMissing(survivor: Application, duplicates: Application[]): Partial<Application> {
+  const merged: Partial<Application> = {};
+  for (const dup of duplicates) {
+    if (!survivor.generated_resume && !merged.generated_resume && dup.generated_resume) {
+      merged.generated_resume = dup.generated_resume;
+    }
+    if (!survivor.generated_cover_letter && !merged.generated_cover_letter && dup.generated_cover_letter) {
+      merged.generated_cover_letter = dup.generated_cover_letter;
+    }
+    if (!survivor.form_data && !merged.form_data && dup.form_data) {
+      merged.form_data = dup.form_data;
+    }
+    if (!survivor.apply_method && !merged.apply_method && dup.apply_method) {
+      merged.apply_method = dup.apply_method;
+    }
+  }
+
+  // Keep every note; the survivor's stay on top
+  const notes = [survivor, ...duplicates]
+    .map((app) => app.notes?.trim())
+    .filter((note): note is string => Boolean(note));
+  const uniqueNotes = [...new Set(notes)];
+  if (uniqueNotes.length > (survivor.notes?.trim() ? 1 : 0)) {
+    merged.notes = uniqueNotes.join('\n');
+  }
+
+  return merged;
+}
+
+/**
+ * Group applications that point at the same posting. Older records were saved
+ * before URLs were normalized, so tracking params and trailing slashes can
+ * make one job look like several. Only pending and failed records are ever
+ * listed as duplicates: a second submitted record is a deliberate
+ * re-application (apply --force) and is kept.
+ */
+export function findDuplicateApplications(applications: Application[]): DuplicateGroup[] {
+  const groups = new Map<string, Application[]>();
+
+  for (const app of applications) {
+    const key = `${app.profile_id}:${normalizeUrl(app.url)}`;
+    const group = groups.get(key);
+    if (group) {
+      group.push(app);
+    } else {
+      groups.set(key, [app]);
+    }
+  }
+
+  const duplicates: DuplicateGroup[] = [];
+  for (const [key, apps] of groups) {
+    if (apps.length < 2) continue;
+
+    const [survivor, ...rest] = [...apps].sort(compareSurvivor);
+    const removable = rest.filter((app) => app.status !== 'submitted');
+    if (removable.length === 0) continue;
+
+    duplicates.push({ key, survivor, duplicates: removable, merged: mergeMissing(survivor, removable) });
+  }
+
+  return duplicates;
+}
@@ -0,0 +1,41 @@
+import { describe, expect, test } from 'bun:test';
+import { DEFAULT_FIT_THRESHOLDS, fitBand, parseMinScore, resolveFitThresholds } from './fit-bands';
+
+describe('parseMinScore', () => {
+  test('accepts whole percentages', () => {
+    expect(parseMinScore('75')).toBe(75);
+    expect(parseMinScore('60%')).toBe(60);
+  });
+
+  test('rejects fractions and out-of-range values', () => {
+    expect(() => parseMinScore('0.7')).toThrow('Invalid score');
+    expect(() => parseMinScore('101')).toThrow('Invalid score');
+    expect(() => parseMinScore('high')).toThrow('Invalid score');
+  });
+});
+
+describe('resolveFitThresholds', () => {
+  test('uses the defaults when nothing is configured', () => {
+    expect(resolveFitThresholds({})).toEqual(DEFAULT_FIT_THRESHOLDS);
+  });
+
+  test('reads config and lets --min-score win', () => {
+    const config = { minFitScore: 80, maybeFitScore: 60 };
+    expect(resolveFitThresholds(config)).toEqual({ match: 80, maybe: 60 });
+    expect(resolveFitThresholds(config, 65)).toEqual({ match: 65, maybe: 60 });
+  });
+
+  test('keeps the maybe band below the match threshold', () => {
+    expect(resolveFitThresholds({}, 40)).toEqual({ match: 40, maybe: 40 });
+  });
+});
+
+describe('fitBand', () => {
+  test('labels scores against the thresholds', () => {
+    expect(fitBand(70)).toBe('strong');
+    expect(fitBand(69)).toBe('maybe');
+    expect(fitBand(50)).toBe('maybe');
+    expect(fitBand(49)).toBe('weak');
+    expect(fitBand(69, { match: 60, maybe: 40 })).toBe('strong');
+  });
+});
@@ -0,0 +1,41 @@
+import type { AppConfig } from '../types';
+
+export type FitBand = 'strong' | 'maybe' | 'weak';
+
+export interface FitThresholds {
+  /** Scores at or above this are a strong match */
+  match: number;
+  /** Scores at or above this (but below match) are worth a look */
+  maybe: number;
+}
+
+export const DEFAULT_FIT_THRESHOLDS: FitThresholds = { match: 70, maybe: 50 };
+
+/** Parse a --min-score value: a whole percentage from 0 to 100. */
+export function parseMinScore(value: string): number {
+  const score = Number(value.trim().replace(/%$/, ''));
+  if (!Number.isInteger(score) || score < 0 || score > 100) {
+    throw new Error(`Invalid score: ${value} (use a whole number from 0 to 100)`);
+  }
+  return score;
+}
+
+/**
+ * Thresholds for apply and match: application.minFitScore and
+ * application.maybeFitScore, with a per-command --min-score taking precedence.
+ * The maybe band never sits above the match threshold.
+ */
+export function resolveFitThresholds(
+  application: Pick<AppConfig['application'], 'minFitScore' | 'maybeFitScore'>,
+  minScore?: number
+): FitThresholds {
+  const match = minScore ?? application.minFitScore ?? DEFAULT_FIT_THRESHOLDS.match;
+  const maybe = Math.min(application.maybeFitScore ?? DEFAULT_FIT_THRESHOLDS.maybe, match);
+  return { match, maybe };
+}
+
+export function fitBand(score: number, thresholds: FitThresholds = DEFAULT_FIT_THRESHOLDS): FitBand {
+  if (score >= thresholds.match) return 'strong';
+  if (score >= thresholds.maybe) return 'maybe';
+  return 'weak';
+}
@@ -1,35 +1,7 @@
 import { describe, expect, test } from 'bun:test';
+import type { Page } from 'playwright';
 import type { Profile, FormField, CustomQuestion, JobData } from '../types';
-
-// Import the FIELD_PATTERNS by recreating them for testing
-// (since they're not exported from form-filler.ts)
-const FIELD_PATTERNS = {
-  firstName: /first[\s_-]?name|given[\s_-]?name|\bfname\b/i,
-  lastName: /last[\s_-]?name|surname|family[\s_-]?name|\blname\b/i,
-  fullName: /full[\s_-]?name|\bname\b|your[\s_-]?name|candidate[\s_-]?name/i,
-  email: /e?[\s_-]?mail|email[\s_-]?address/i,
-  phone: /phone|tel|mobile|cell|contact[\s_-]?number/i,
-  location: /location|city|address|where.*based|current[\s_-]?location/i,
-  linkedin: /linkedin|li[\s_-]?url|li[\s_-]?profile/i,
-  github: /github|gh[\s_-]?url|gh[\s_-]?profile/i,
-  portfolio: /portfolio|website|personal[\s_-]?site|url|homepage/i,
-  resume: /resume|cv|curriculum[\s_-]?vitae/i,
-  coverLetter: /cover[\s_-]?letter|covering[\s_-]?letter|motivation[\s_-]?letter/i,
-  workAuthorization: /work[\s_-]?auth|authorized[\s_-]?to[\s_-]?work|legally[\s_-]?authorized|eligib|visa[\s_-]?status|right[\s_-]?to[\s_-]?work/i,
-  sponsorship: /sponsor|visa[\s_-]?sponsor|immigration[\s_-]?sponsor|require.*sponsor/i,
-  yearsExperience: /years?[\s_-]?(?:of[\s_-]?)?experience|experience[\s_-]?years|how[\s_-]?many[\s_-]?years/i,
-  currentCompany: /current[\s_-]?company|employer|where.*work/i,
-  currentTitle: /current[\s_-]?title|current[\s_-]?role|job[\s_-]?title/i,
-  salary: /salary|compensation|pay|expected[\s_-]?salary|desired[\s_-]?salary/i,
-  startDate: /start[\s_-]?date|when.*start|available.*start|availability|earliest[\s_-]?start/i,
-  noticePeriod: /notice[\s_-]?period|notice|how[\s_-]?soon/i,
-  gender: /gender|sex/i,
-  ethnicity: /ethnicity|race|ethnic[\s_-]?background/i,
-  veteran: /veteran|military[\s_-]?service/i,
-  disability: /disability|disabled/i,
-  referral: /referral|how.*hear|source|where.*find|referred[\s_-]?by/i,
-  relocation: /relocation|willing[\s_-]?to[\s_-]?relocate|open[\s_-]?to[\s_-]?relocate/i,
-} as const;
+import { FormFiller, FIELD_PATTERNS, resolveFieldPatterns } from './form-filler';
 
 describe('FormFiller Field Patterns', () => {
   describe('firstName pattern', () => {
@@ -642,3 +614,77 @@ describe('Years of Experience Calculation', () => {
     expect(calculateYearsExperience(experience)).toBe('2');
   });
 });
+
+describe('resolveFieldPatterns', () => {
+  test('returns the built-in patterns without overrides', () => {
+    const { patterns, errors } = resolveFieldPatterns(undefined, 'greenhouse');
+    expect(patterns.email).toBe(FIELD_PATTERNS.email);
+    expect(errors).toEqual([]);
+  });
+
+  test('applies global then platform-specific overrides', () => {
+    const { patterns } = resolveFieldPatterns(
+      {
+        '*': { email: 'correo', phone: 'telefono' },
+        lever: { phone: 'handynummer' },
+      },
+      'lever'
+    );
+
+    expect(patterns.email.test('Correo electrónico')).toBe(true);
+    expect(patterns.phone.test('Handynummer')).toBe(true);
+    expect(patterns.phone.test('telefono')).toBe(false);
+    expect(patterns.firstName).toBe(FIELD_PATTERNS.firstName);
+  });
+
+  test('ignores overrides for other platforms', () => {
+    const { patterns } = resolveFieldPatterns({ lever: { email: 'correo' } }, 'greenhouse');
+    expect(patterns.email).toBe(FIELD_PATTERNS.email);
+  });
+
+  test('reports unknown fields and invalid regexes without failing', () => {
+    const { patterns, errors } = resolveFieldPatterns({ '*': { favouriteColour: 'colou?r', email: '(' } });
+
+    expect(errors).toHaveLength(2);
+    expect(errors[0]).toContain('fieldPatterns.*.favouriteColour: unknown field');
+    expect(errors[1]).toContain('fieldPatterns.*.email: invalid regex');
+    expect(patterns.email).toBe(FIELD_PATTERNS.email);
+  });
+});
+
+describe('FormFiller with custom field patterns', () => {
+  const profile: Profile = {
+    name: 'Ada Lovelace',
+    email: 'ada@example.com',
+    phone: '+1 555 0100',
+    skills: [],
+    experience: [],
+    education: [],
+  };
+
+  const jobData: JobData = {
+    url: 'https://jobs.lever.co/acme/1',
+    platform: 'lever',
+    title: 'Engineer',
+    company: 'Acme',
+    description: '',
+    requirements: [],
+    qualifications: [],
+    form_fields: [],
+    custom_questions: [],
+  };
+
+  const field: FormField = { name: 'kontakt_nr', label: 'Kontaktnummer', type: 'tel', required: true };
+
+  test('the default patterns do not recognise the field', () => {
+    const filler = new FormFiller({} as Page, profile, jobData, { fieldPatterns: {} });
+    expect(filler.getValueForField(field)).toBeNull();
+  });
+
+  test('a custom mapping is used when filling', () => {
+    const filler = new FormFiller({} as Page, profile, jobData, {
+      fieldPatterns: { lever: { phone: 'kontakt' } },
+    });
+    expect(filler.getValueForField(field)).toBe('+1 555 0100');
+  });
+});
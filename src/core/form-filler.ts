@@ -1,11 +1,14 @@
 import type { Page } from 'playwright';
-import type { Profile, FormField, CustomQuestion, JobData } from '../types';
+import type { Profile, FormField, CustomQuestion, JobData, Platform, FieldPatternOverrides } from '../types';
 import { join } from 'path';
 import { getAutoplyDir } from '../db';
 import { configRepository } from '../db/repositories/config';
+import { totalExperienceYears } from '../utils/experience';
+import { logger } from '../utils/logger';
+import { splitName } from '../utils/name';
 
 // Field matching patterns for common form fields
-const FIELD_PATTERNS = {
+export const FIELD_PATTERNS = {
   // Personal information
   firstName: /first[\s_-]?name|given[\s_-]?name|\bfname\b/i,
   lastName: /last[\s_-]?name|surname|family[\s_-]?name|\blname\b/i,
@@ -49,7 +52,48 @@ const FIELD_PATTERNS = {
   // Other
   referral: /referral|how.*hear|source|where.*find|referred[\s_-]?by/i,
   relocation: /relocation|willing[\s_-]?to[\s_-]?relocate|open[\s_-]?to[\s_-]?relocate/i,
-} as const;
+};
+
+export type FieldPatternKey = keyof typeof FIELD_PATTERNS;
+export type FieldPatterns = Record<FieldPatternKey, RegExp>;
+
+/**
+ * Build the field patterns for a platform: built-in defaults, then "*"
+ * overrides, then platform-specific ones. Invalid entries are skipped and
+ * reported so a typo in config can't break form filling.
+ */
+export function resolveFieldPatterns(
+  overrides: FieldPatternOverrides | undefined,
+  platform?: Platform
+): { patterns: FieldPatterns; errors: string[] } {
+  const patterns: FieldPatterns = { ...FIELD_PATTERNS };
+  const errors: string[] = [];
+
+  const layers: Array<['*' | Platform, Record<string, string> | undefined]> = [['*', overrides?.['*']]];
+  if (platform) layers.push([platform, overrides?.[platform]]);
+
+  for (const [scope, layer] of layers) {
+    if (!layer) continue;
+    for (const [key, source] of Object.entries(layer)) {
+      if (!(key in FIELD_PATTERNS)) {
+        errors.push(`fieldPatterns.${scope}.${key}: unknown field (expected one of ${Object.keys(FIELD_PATTERNS).join(', ')})`);
+        continue;
+      }
+      if (typeof source !== 'string' || !source.trim()) {
+        errors.push(`fieldPatterns.${scope}.${key}: pattern must be a non-empty string`);
+        continue;
+      }
+      try {
+        patterns[key as FieldPatternKey] = new RegExp(source, 'i');
+      } catch (error) {
+        const msg = error instanceof Error ? error.message : String(error);
+        errors.push(`fieldPatterns.${scope}.${key}: invalid regex (${msg})`);
+      }
+    }
+  }
+
+  return { patterns, errors };
+}
 
 export interface FormFillerOptions {
   resumePath?: string;
@@ -59,6 +103,8 @@ export interface FormFillerOptions {
   interactivePrompts?: boolean;
   /** When true, skip all interactive prompts (e.g. --auto mode) */
   autoMode?: boolean;
+  /** Field pattern overrides; read from config when not set */
+  fieldPatterns?: FieldPatternOverrides;
 }
 
 export interface FillResult {
@@ -74,11 +120,20 @@ export class FormFiller {
   private jobData: JobData;
   private options: FormFillerOptions;
 
+  private patterns: FieldPatterns;
+
   constructor(page: Page, profile: Profile, jobData: JobData, options: FormFillerOptions = {}) {
     this.page = page;
     this.profile = profile;
     this.jobData = jobData;
     this.options = options;
+
+    const overrides = options.fieldPatterns ?? configRepository.loadAppConfig().fieldPatterns;
+    const { patterns, errors } = resolveFieldPatterns(overrides, jobData.platform);
+    for (const error of errors) {
+      logger.warning(`Ignoring ${error}`);
+    }
+    this.patterns = patterns;
   }
 
   async fillForm(formFields: FormField[]): Promise<FillResult> {
@@ -195,96 +250,99 @@ export class FormFiller {
     }
   }
 
-  private getValueForField(field: FormField): string | null {
+  /**
+   * The profile value a field should be filled with, or null if none of the
+   * field patterns match.
+   */
+  getValueForField(field: FormField): string | null {
     const label = (field.label || '').toLowerCase();
     const name = (field.name || '').toLowerCase();
     const combined = `${label} ${name}`;
 
     // First Name
-    if (FIELD_PATTERNS.firstName.test(combined)) {
-      return this.profile.name.split(' ')[0] || null;
+    if (this.patterns.firstName.test(combined)) {
+      return splitName(this.profile.name).first || null;
     }
 
     // Last Name
-    if (FIELD_PATTERNS.lastName.test(combined)) {
-      const parts = this.profile.name.split(' ');
-      return parts.length > 1 ? parts.slice(1).join(' ') : null;
+    if (this.patterns.lastName.test(combined)) {
+      return splitName(this.profile.name).last || null;
     }
 
     // Full Name
-    if (FIELD_PATTERNS.fullName.test(combined)) {
+    if (this.patterns.fullName.test(combined)) {
       return this.profile.name;
     }
 
     // Email
-    if (FIELD_PATTERNS.email.test(combined)) {
+    if (this.patterns.email.test(combined)) {
       return this.profile.email;
     }
 
     // Phone
-    if (FIELD_PATTERNS.phone.test(combined)) {
+    if (this.patterns.phone.test(combined)) {
       return this.profile.phone || null;
     }
 
     // Location
-    if (FIELD_PATTERNS.location.test(combined)) {
+    if (this.patterns.location.test(combined)) {
       return this.profile.location || null;
     }
 
     // LinkedIn
-    if (FIELD_PATTERNS.linkedin.test(combined)) {
+    if (this.patterns.linkedin.test(combined)) {
       return this.profile.linkedin_url || null;
     }
 
     // GitHub
-    if (FIELD_PATTERNS.github.test(combined)) {
+    if (this.patterns.github.test(combined)) {
       return this.profile.github_url || null;
     }
 
     // Portfolio
-    if (FIELD_PATTERNS.portfolio.test(combined)) {
+    if (this.patterns.portfolio.test(combined)) {
       return this.profile.portfolio_url || null;
     }
 
     // Work Authorization - typically "Yes" for most applicants
-    if (FIELD_PATTERNS.workAuthorization.test(combined)) {
+    if (this.patterns.workAuthorization.test(combined)) {
       return 'Yes';
     }
 
     // Sponsorship - default to No (can be customized)
-    if (FIELD_PATTERNS.sponsorship.test(combined)) {
+    if (this.patterns.sponsorship.test(combined)) {
       return 'No';
     }
 
     // Years of experience
-    if (FIELD_PATTERNS.yearsExperience.test(combined)) {
+    if (this.patterns.yearsExperience.test(combined)) {
       return this.calculateYearsExperience();
     }
 
     // Current company
-    if (FIELD_PATTERNS.currentCompany.test(combined)) {
+    if (this.patterns.currentCompany.test(combined)) {
       const latestExp = this.profile.experience[0];
       return latestExp?.company || null;
     }
 
     // Current title
-    if (FIELD_PATTERNS.currentTitle.test(combined)) {
+    if (this.patterns.currentTitle.test(combined)) {
       const latestExp = this.profile.experience[0];
       return latestExp?.title || null;
     }
 
     // Start date / availability
-    if (FIELD_PATTERNS.startDate.test(combined) || FIELD_PATTERNS.noticePeriod.test(combined)) {
+    if (this.patterns.startDate.test(combined) || this.patterns.noticePeriod.test(combined)) {
       return '2 weeks';
     }
 
     // Referral / How did you hear
-    if (FIELD_PATTERNS.referral.test(combined)) {
+    if (this.patterns.referral.test(combined)) {
       return 'Online Job Board';
     }
 
     // Relocation
-    if (FIELD_PATTERNS.relocation.test(combined)) {
+    if (this.patterns.relocation.test(combined)) {
       return this.profile.preferences?.remote_only ? 'No' : 'Yes';
     }
 
@@ -304,20 +362,7 @@ export class FormFiller {
   }
 
   private calculateYearsExperience(): string {
-    if (this.profile.experience.length === 0) {
-      return '0';
-    }
-
-    let totalMonths = 0;
-    for (const exp of this.profile.experience) {
-      const start = new Date(exp.start_date);
-      const end = exp.end_date ? new Date(exp.end_date) : new Date();
-      const months = (end.getFullYear() - start.getFullYear()) * 12 + (end.getMonth() - start.getMonth());
-      totalMonths += Math.max(0, months);
-    }
-
-    const years = Math.round(totalMonths / 12);
-    return years.toString();
+    return Math.round(totalExperienceYears(this.profile.experience)).toString();
   }
 
   private buildSelector(field: FormField): string {
@@ -472,9 +517,9 @@ export class FormFiller {
     let filePath: string | null = null;
 
     // Determine which file to upload
-    if (FIELD_PATTERNS.resume.test(combined)) {
+    if (this.patterns.resume.test(combined)) {
       filePath = this.options.resumePath || null;
-    } else if (FIELD_PATTERNS.coverLetter.test(combined)) {
+    } else if (this.patterns.coverLetter.test(combined)) {
       filePath = this.options.coverLetterPath || null;
     }
 
@@ -0,0 +1,105 @@
+import { describe, expect, test } from 'bun:test';
+import { formatUrlList, parseApplicationId, parseHistoryFields, renderHistoryTable, sortApplications } from './history-view';
+import { parseUrlList } from '../utils/url-parser';
+import type { Application } from '../types';
+
+function app(overrides: Partial<Application>): Application {
+  return {
+    id: 1,
+    profile_id: 1,
+    url: 'https://example.com/job',
+    platform: 'greenhouse',
+    company: 'Acme',
+    job_title: 'Engineer',
+    status: 'submitted',
+    created_at: '2026-01-01 10:00:00',
+    ...overrides,
+  };
+}
+
+describe('parseHistoryFields', () => {
+  test('accepts known fields case-insensitively', () => {
+    expect(parseHistoryFields('Title, company ,status')).toEqual(['title', 'company', 'status']);
+  });
+
+  test('rejects unknown fields', () => {
+    expect(() => parseHistoryFields('title,salary')).toThrow('Unknown field(s): salary');
+  });
+});
+
+describe('renderHistoryTable', () => {
+  const apps = [
+    app({ id: 1, job_title: 'Engineer', company: 'Acme' }),
+    app({ id: 2, job_title: 'Senior Platform Engineer', company: 'Globex', status: 'failed' }),
+  ];
+
+  test('prints only the selected columns', () => {
+    const lines = renderHistoryTable(apps, ['title', 'company']);
+
+    expect(lines[0]).toBe('TITLE                     COMPANY');
+    expect(lines.join('\n')).not.toContain('submitted');
+    expect(lines.join('\n')).not.toContain('https://');
+  });
+
+  test('aligns columns', () => {
+    const lines = renderHistoryTable(apps, ['title', 'company', 'status']);
+    const companyCol = lines[0].indexOf('COMPANY');
+    const statusCol = lines[0].indexOf('STATUS');
+
+    expect(lines[1].indexOf('Acme')).toBe(companyCol);
+    expect(lines[2].indexOf('Globex')).toBe(companyCol);
+    expect(lines[2].indexOf('failed')).toBe(statusCol);
+  });
+});
+
+describe('sortApplications', () => {
+  const apps = [
+    app({ id: 1, company: 'globex', created_at: '2026-01-01' }),
+    app({ id: 2, company: 'Acme', created_at: '2026-03-01' }),
+    app({ id: 3, company: 'Initech', applied_at: '2026-02-01', created_at: '2025-12-01' }),
+  ];
+
+  test('sorts by date newest first, preferring applied_at', () => {
+    expect(sortApplications(apps, 'date').map((a) => a.id)).toEqual([2, 3, 1]);
+  });
+
+  test('sorts text fields case-insensitively', () => {
+    expect(sortApplications(apps, 'company').map((a) => a.id)).toEqual([2, 1, 3]);
+  });
+});
+
+describe('formatUrlList', () => {
+  test('writes one URL per line without duplicates', () => {
+    const apps = [
+      app({ id: 1, url: 'https://jobs.lever.co/acme/1' }),
+      app({ id: 2, url: 'https://jobs.lever.co/acme/1/?utm_source=x' }),
+      app({ id: 3, url: 'https://boards.greenhouse.io/globex/jobs/2' }),
+    ];
+
+    expect(formatUrlList(apps)).toBe('https://jobs.lever.co/acme/1\nhttps://boards.greenhouse.io/globex/jobs/2\n');
+  });
+
+  test('round-trips through the apply --file parser', () => {
+    const apps = [app({ url: 'https://jobs.lever.co/acme/1' }), app({ url: 'https://boards.greenhouse.io/globex/jobs/2' })];
+    expect(parseUrlList(formatUrlList(apps), 'text')).toEqual(apps.map((a) => a.url));
+  });
+
+  test('is empty when there is nothing to write', () => {
+    expect(formatUrlList([])).toBe('');
+  });
+});
+
+describe('parseApplicationId', () => {
+  test('accepts plain and #-prefixed ids', () => {
+    expect(parseApplicationId('12')).toBe(12);
+    expect(parseApplicationId(' #7 ')).toBe(7);
+  });
+
+  test('rejects partial, zero and negative ids', () => {
+    expect(() => parseApplicationId('12abc')).toThrow('Invalid application id: 12abc');
+    expect(() => parseApplicationId('0')).toThrow();
+    expect(() => parseApplicationId('-3')).toThrow();
+    expect(() => parseApplicationId('')).toThrow();
+  });
+});
+
@@ -0,0 +1,97 @@
+import type { Application } from '../types';
+import { formatTable, truncate } from '../utils/table';
+import { normalizeUrl } from '../utils/url-parser';
+
+interface HistoryField {
+  header: string;
+  value: (app: Application) => string;
+}
+
+function formatDate(value?: string): string {
+  if (!value) return '';
+  const date = new Date(value);
+  return Number.isNaN(date.getTime()) ? value : date.toISOString().slice(0, 10);
+}
+
+export const HISTORY_FIELDS: Record<string, HistoryField> = {
+  id: { header: 'ID', value: (app) => String(app.id ?? '') },
+  title: { header: 'TITLE', value: (app) => truncate(app.job_title, 40) },
+  company: { header: 'COMPANY', value: (app) => truncate(app.company, 25) },
+  status: { header: 'STATUS', value: (app) => app.status },
+  platform: { header: 'PLATFORM', value: (app) => app.platform },
+  date: { header: 'DATE', value: (app) => formatDate(app.applied_at ?? app.created_at) },
+  url: { header: 'URL', value: (app) => app.url },
+};
+
+export const HISTORY_SORT_KEYS = ['date', 'title', 'company', 'status'] as const;
+export type HistorySortKey = (typeof HISTORY_SORT_KEYS)[number];
+
+/**
+ * Parse a comma-separated --fields value. Throws on unknown names so typos
+ * don't silently drop columns.
+ */
+export function parseHistoryFields(value: string): string[] {
+  const fields = value.split(',').map((f) => f.trim().toLowerCase()).filter(Boolean);
+  const unknown = fields.filter((f) => !(f in HISTORY_FIELDS));
+  if (unknown.length > 0) {
+    throw new Error(`Unknown field(s): ${unknown.join(', ')}. Available: ${Object.keys(HISTORY_FIELDS).join(', ')}`);
+  }
+  if (fields.length === 0) {
+    throw new Error(`No fields given. Available: ${Object.keys(HISTORY_FIELDS).join(', ')}`);
+  }
+  return fields;
+}
+
+/**
+ * Parse an application id from the command line. Rejects partial numbers
+ * like "12abc" that parseInt would quietly accept.
+ */
+export function parseApplicationId(value: string): number {
+  const trimmed = value.trim().replace(/^#/, '');
+  if (!/^\d+$/.test(trimmed) || Number(trimmed) === 0) {
+    throw new Error(`Invalid application id: ${value}`);
+  }
+  return Number(trimmed);
+}
+
+export function isHistorySortKey(value: string): value is HistorySortKey {
+  return (HISTORY_SORT_KEYS as readonly string[]).includes(value);
+}
+
+/**
+ * Dates sort newest first; text fields sort alphabetically.
+ */
+export function sortApplications(applications: Application[], key: HistorySortKey): Application[] {
+  const sorted = [...applications];
+  if (key === 'date') {
+    const time = (app: Application) => new Date(app.applied_at ?? app.created_at ?? 0).getTime() || 0;
+    return sorted.sort((a, b) => time(b) - time(a));
+  }
+
+  const text = (app: Application) =>
+    key === 'title' ? app.job_title : key === 'company' ? app.company : app.status;
+  return sorted.sort((a, b) => text(a).localeCompare(text(b), undefined, { sensitivity: 'base' }));
+}
+
+export function renderHistoryTable(applications: Application[], fields: string[]): string[] {
+  const columns = fields.map((f) => HISTORY_FIELDS[f]);
+  return formatTable(
+    columns.map((c) => c.header),
+    applications.map((app) => columns.map((c) => c.value(app)))
+  );
+}
+
+/**
+ * One URL per line, duplicates removed, in the format `apply --file` reads.
+ */
+export function formatUrlList(applications: Application[]): string {
+  const seen = new Set<string>();
+  const urls: string[] = [];
+  for (const app of applications) {
+    const key = normalizeUrl(app.url);
+    if (seen.has(key)) continue;
+    seen.add(key);
+    urls.push(app.url);
+  }
+  return urls.length > 0 ? `${urls.join('\n')}\n` : '';
+}
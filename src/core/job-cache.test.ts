@@ -0,0 +1,89 @@
+import { describe, expect, test, beforeEach } from 'bun:test';
+import { useTempDataDir } from '../db/test-helpers';
+import { jobCacheRepository } from '../db/repositories/job-cache';
+import { loadJobData } from './job-cache';
+import type { JobData, Platform } from '../types';
+
+const URL = 'https://boards.greenhouse.io/acme/jobs/123';
+
+let scrapeCalls: number;
+
+function fakeScrape(url: string, platform: Platform): Promise<JobData> {
+  scrapeCalls++;
+  const job: JobData = {
+    url,
+    platform,
+    title: `Engineer ${scrapeCalls}`,
+    company: 'Acme',
+    description: 'Build things',
+    requirements: [],
+    qualifications: [],
+    form_fields: [],
+    custom_questions: [],
+  };
+  return Promise.resolve(job);
+}
+
+useTempDataDir();
+
+beforeEach(() => {
+  scrapeCalls = 0;
+});
+
+describe('loadJobData', () => {
+  test('second load within the TTL comes from the cache', async () => {
+    const first = await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape });
+    const second = await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape });
+
+    expect(scrapeCalls).toBe(1);
+    expect(first.cached).toBe(false);
+    expect(second.cached).toBe(true);
+    expect(second.jobData.title).toBe('Engineer 1');
+  });
+
+  test('tracking parameters map to the same cache entry', async () => {
+    await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape });
+    await loadJobData(`${URL}?utm_source=linkedin`, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape });
+
+    expect(scrapeCalls).toBe(1);
+  });
+
+  test('noCache scrapes again and refreshes the entry', async () => {
+    await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape });
+    const fresh = await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, noCache: true, scrape: fakeScrape });
+    const after = await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape });
+
+    expect(scrapeCalls).toBe(2);
+    expect(fresh.cached).toBe(false);
+    expect(after.jobData.title).toBe('Engineer 2');
+  });
+
+  test('expired entries are scraped again', async () => {
+    const job = await fakeScrape(URL, 'greenhouse');
+    jobCacheRepository.save(URL, 'greenhouse', job, Date.now() - 2 * 60 * 60_000);
+
+    const result = await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape });
+
+    expect(result.cached).toBe(false);
+    expect(scrapeCalls).toBe(2);
+  });
+
+  test('a TTL of 0 disables the cache', async () => {
+    await loadJobData(URL, 'greenhouse', { ttlMinutes: 0, scrape: fakeScrape });
+    await loadJobData(URL, 'greenhouse', { ttlMinutes: 0, scrape: fakeScrape });
+
+    expect(scrapeCalls).toBe(2);
+  });
+
+  test('refuses pages robots.txt disallows when asked to respect it', async () => {
+    const robots = { isAllowed: async () => false };
+
+    await expect(
+      loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape, respectRobots: true, robots })
+    ).rejects.toThrow('disallowed by robots.txt');
+    expect(scrapeCalls).toBe(0);
+
+    await loadJobData(URL, 'greenhouse', { ttlMinutes: 60, scrape: fakeScrape, respectRobots: false, robots });
+    expect(scrapeCalls).toBe(1);
+  });
+});
@@ -0,0 +1,59 @@
+import type { JobData, Platform } from '../types';
+import { scrapeJob } from '../scrapers';
+import { jobCacheRepository } from '../db/repositories/job-cache';
+import { configRepository } from '../db/repositories/config';
+import { logger } from '../utils/logger';
+import { isIgnoringRobots, robotsChecker, RobotsDisallowedError } from '../utils/robots';
+
+export const DEFAULT_CACHE_TTL_MINUTES = 60;
+
+export interface LoadJobOptions {
+  /** Skip the cache lookup and always scrape (the fresh result is still cached) */
+  noCache?: boolean;
+  /** Overrides browser.cacheTtlMinutes; 0 disables caching */
+  ttlMinutes?: number;
+  /** Scraper to call on a cache miss; defaults to the platform scraper */
+  scrape?: (url: string, platform: Platform) => Promise<JobData>;
+  /** Check robots.txt before scraping; defaults to browser.respectRobotsTxt unless --ignore-robots is set */
+  respectRobots?: boolean;
+  robots?: { isAllowed(url: string): Promise<boolean> };
+}
+
+export interface LoadedJob {
+  jobData: JobData;
+  cached: boolean;
+}
+
+/**
+ * Scrape a job page, reusing a recent result for the same URL so that
+ * generate followed by apply doesn't open the browser twice.
+ */
+export async function loadJobData(
+  url: string,
+  platform: Platform,
+  options: LoadJobOptions = {}
+): Promise<LoadedJob> {
+  const browserConfig = configRepository.loadAppConfig().browser;
+  const ttlMinutes = options.ttlMinutes ?? browserConfig.cacheTtlMinutes ?? DEFAULT_CACHE_TTL_MINUTES;
+  const respectRobots = options.respectRobots ?? (browserConfig.respectRobotsTxt === true && !isIgnoringRobots());
+  const scrape = options.scrape ?? scrapeJob;
+  const useCache = ttlMinutes > 0;
+
+  if (useCache && !options.noCache) {
+    const hit = jobCacheRepository.get(url, ttlMinutes * 60_000);
+    if (hit) {
+      logger.debug(`Using cached job data for ${url} (${new Date(hit.cachedAt).toISOString()})`);
+      return { jobData: hit.jobData, cached: true };
+    }
+  }
+
+  if (respectRobots && !(await (options.robots ?? robotsChecker).isAllowed(url))) {
+    throw new RobotsDisallowedError(url);
+  }
+
+  const jobData = await scrape(url, platform);
+  if (useCache) {
+    jobCacheRepository.save(url, platform, jobData);
+  }
+  return { jobData, cached: false };
+}
@@ -0,0 +1,67 @@
+import { describe, expect, test } from 'bun:test';
+import { buildMatchReport, findResumeGaps, formatMatchReport } from './match-report';
+import type { JobFitResult } from '../ai/job-matcher';
+import type { JobData } from '../types';
+
+const jobData: JobData = {
+  url: 'https://jobs.lever.co/acme/1',
+  platform: 'lever',
+  title: 'Backend Engineer',
+  company: 'Acme',
+  description: 'Build APIs in Go',
+  requirements: ['Go', 'Kubernetes'],
+  qualifications: [],
+  form_fields: [],
+  custom_questions: [],
+};
+
+const fit: JobFitResult = {
+  score: 70,
+  reasoning: 'Solid backend match.',
+  strongMatches: ['Go', 'PostgreSQL'],
+  missingSkills: ['Kubernetes'],
+  recommendation: 'good',
+  breakdown: { skills: 80, experience: 60, location: 100, title: 50 },
+};
+
+describe('findResumeGaps', () => {
+  test('lists job skills the resume never mentions', () => {
+    expect(findResumeGaps(fit, 'Backend engineer. Go, gRPC.')).toEqual(['PostgreSQL', 'Kubernetes']);
+  });
+
+  test('is empty without a base resume', () => {
+    expect(findResumeGaps(fit, undefined)).toEqual([]);
+  });
+});
+
+describe('formatMatchReport', () => {
+  test('prints every section', () => {
+    const lines = formatMatchReport(buildMatchReport(jobData, fit, 'Go and PostgreSQL'));
+
+    expect(lines[0]).toBe('Backend Engineer at Acme');
+    expect(lines).toContain('Score: 70%');
+    expect(lines).toContain('Breakdown:');
+    expect(lines.some((l) => l.includes('Skills') && l.includes('80%'))).toBe(true);
+    expect(lines).toContain('  Matched: Go, PostgreSQL');
+    expect(lines).toContain('  Missing: Kubernetes');
+    expect(lines).toContain('  Not mentioned in your resume: Kubernetes');
+    expect(lines).toContain('Recommendation: apply');
+    expect(lines).toContain('  Solid backend match.');
+  });
+
+  test('labels a score in the maybe band and skips the breakdown when absent', () => {
+    const lines = formatMatchReport(buildMatchReport(jobData, { ...fit, score: 55, breakdown: undefined }));
+
+    expect(lines).not.toContain('Breakdown:');
+    expect(lines).toContain('Recommendation: maybe');
+    expect(lines).toContain('  none found');
+  });
+});
+
+describe('buildMatchReport', () => {
+  test('uses the given thresholds for the verdict', () => {
+    expect(buildMatchReport(jobData, fit).verdict).toBe('apply');
+    expect(buildMatchReport(jobData, fit, undefined, { match: 80, maybe: 60 }).verdict).toBe('maybe');
+    expect(buildMatchReport(jobData, fit, undefined, { match: 90, maybe: 75 }).verdict).toBe('skip');
+  });
+});
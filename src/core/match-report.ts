@@ -0,0 +1,91 @@
+import type { JobData } from '../types';
+import { explainFit, type JobFitResult } from '../ai/job-matcher';
+import { mentions } from '../utils/mentions';
+import { DEFAULT_FIT_THRESHOLDS, fitBand, type FitBand, type FitThresholds } from './fit-bands';
+
+export type MatchVerdict = 'apply' | 'maybe' | 'skip';
+
+export interface MatchReport {
+  title: string;
+  company: string;
+  score: number;
+  verdict: MatchVerdict;
+  /** Per-factor lines from explainFit */
+  factors: string[];
+  matched: string[];
+  missing: string[];
+  /** Skills the job wants that the base resume never mentions */
+  resumeGaps: string[];
+  reasoning: string;
+}
+
+const VERDICTS: Record<FitBand, MatchVerdict> = {
+  strong: 'apply',
+  maybe: 'maybe',
+  weak: 'skip',
+};
+
+/**
+ * Job skills missing from the resume text: the model's missing skills, plus
+ * matched skills the candidate has but doesn't mention (worth adding before
+ * applying). Without a base resume there's nothing to compare.
+ */
+export function findResumeGaps(fit: JobFitResult, resumeText: string | undefined): string[] {
+  if (!resumeText?.trim()) return [];
+  const terms = [...fit.strongMatches, ...fit.missingSkills];
+  const seen = new Set<string>();
+  return terms.filter((term) => {
+    const key = term.trim().toLowerCase();
+    if (!key || seen.has(key)) return false;
+    seen.add(key);
+    return !mentions(resumeText, term);
+  });
+}
+
+export function buildMatchReport(
+  jobData: JobData,
+  fit: JobFitResult,
+  resumeText?: string,
+  thresholds: FitThresholds = DEFAULT_FIT_THRESHOLDS
+): MatchReport {
+  return {
+    title: jobData.title,
+    company: jobData.company,
+    score: fit.score,
+    verdict: VERDICTS[fitBand(fit.score, thresholds)],
+    factors: explainFit({ ...fit, strongMatches: [], missingSkills: [], reasoning: '' }),
+    matched: fit.strongMatches,
+    missing: fit.missingSkills,
+    resumeGaps: findResumeGaps(fit, resumeText),
+    reasoning: fit.reasoning,
+  };
+}
+
+/** Plain-text report, one section per heading. */
+export function formatMatchReport(report: MatchReport): string[] {
+  const list = (items: string[]) => (items.length > 0 ? items.join(', ') : 'none');
+  const lines = [
+    `${report.title} at ${report.company}`,
+    '',
+    `Score: ${report.score}%`,
+  ];
+
+  if (report.factors.length > 0) {
+    lines.push('', 'Breakdown:', ...report.factors.map((line) => `  ${line}`));
+  }
+
+  lines.push(
+    '',
+    'Skills:',
+    `  Matched: ${list(report.matched)}`,
+    `  Missing: ${list(report.missing)}`,
+    '',
+    'Resume gaps:',
+    `  ${report.resumeGaps.length > 0 ? `Not mentioned in your resume: ${report.resumeGaps.join(', ')}` : 'none found'}`,
+    '',
+    `Recommendation: ${report.verdict}`,
+  );
+  if (report.reasoning) lines.push(`  ${report.reasoning}`);
+
+  return lines;
+}
@@ -0,0 +1,39 @@
+import { describe, expect, test } from 'bun:test';
+import { existsSync } from 'fs';
+import { join } from 'path';
+import { getAutoplyDir } from '../db';
+import { useTempDataDir } from '../db/test-helpers';
+import { jobDocumentFilename, resolveOutputDir } from './output-path';
+
+useTempDataDir();
+
+describe('resolveOutputDir', () => {
+  test('defaults to output/ in the data directory and creates it', () => {
+    const dir = resolveOutputDir();
+
+    expect(dir).toBe(join(getAutoplyDir(), 'output'));
+    expect(existsSync(dir)).toBe(true);
+  });
+
+  test('creates a configured directory, including missing parents', () => {
+    const base = getAutoplyDir();
+
+    const dir = resolveOutputDir(` ${join(base, 'docs', 'pdfs')} `);
+
+    expect(dir).toBe(join(base, 'docs', 'pdfs'));
+    expect(existsSync(dir)).toBe(true);
+  });
+});
+
+describe('jobDocumentFilename', () => {
+  test('names files {company}-{job}-{type}.pdf using the posting id', () => {
+    const job = { company: 'Acme Corp', title: 'Senior Engineer', url: 'https://boards.greenhouse.io/acme/jobs/4012345' };
+    expect(jobDocumentFilename(job, 'resume')).toBe('acme-corp-4012345-resume.pdf');
+    expect(jobDocumentFilename(job, 'cover-letter')).toBe('acme-corp-4012345-cover-letter.pdf');
+  });
+
+  test('falls back to the title when the URL has no id', () => {
+    const job = { company: 'Acme', title: 'Staff Engineer', url: 'https://jobs.example.com/careers/staff-engineer' };
+    expect(jobDocumentFilename(job, 'resume')).toBe('acme-staff-engineer-resume.pdf');
+  });
+});
@@ -0,0 +1,24 @@
+import { mkdirSync } from 'fs';
+import { join, resolve } from 'path';
+import type { JobData } from '../types';
+import { expandHome, getAutoplyDir } from '../db';
+import { packageDirName } from './batch-generate';
+
+/**
+ * Where generated documents go when no path is given on the command line:
+ * application.outputDir, or ~/.autoply/output. Created if it doesn't exist.
+ */
+export function resolveOutputDir(configured?: string): string {
+  const dir = configured?.trim() ? resolve(expandHome(configured.trim())) : join(getAutoplyDir(), 'output');
+  mkdirSync(dir, { recursive: true });
+  return dir;
+}
+
+/**
+ * File name for a document generated into the shared output directory,
+ * e.g. "acme-4012345-cover-letter.pdf". Regenerating for the same job
+ * replaces the previous file.
+ */
+export function jobDocumentFilename(jobData: Pick<JobData, 'company' | 'title' | 'url'>, type: 'resume' | 'cover-letter'): string {
+  return `${packageDirName(jobData, jobData.url)}-${type}.pdf`;
+}
@@ -0,0 +1,65 @@
+import { describe, expect, test } from 'bun:test';
+import { describePreferences, parseSalaryOption, splitListOption, updatePreferences } from './preferences';
+import type { Preferences } from '../types';
+
+const current: Preferences = {
+  remote_only: false,
+  min_salary: 100000,
+  preferred_locations: ['Berlin'],
+  excluded_companies: ['Initech'],
+  job_types: ['full-time'],
+};
+
+describe('splitListOption', () => {
+  test('accepts repeated and comma-separated values', () => {
+    expect(splitListOption(['Berlin', ' Remote, EU ', ''])).toEqual(['Berlin', 'Remote', 'EU']);
+  });
+});
+
+describe('parseSalaryOption', () => {
+  test('reads formatted amounts and clears on none or zero', () => {
+    expect(parseSalaryOption('$120,000')).toBe(120000);
+    expect(parseSalaryOption('none')).toBeNull();
+    expect(parseSalaryOption('0')).toBeNull();
+  });
+
+  test('rejects negative and non-numeric amounts', () => {
+    expect(() => parseSalaryOption('-1')).toThrow('Invalid --min-salary');
+    expect(() => parseSalaryOption('lots')).toThrow('Invalid --min-salary');
+  });
+});
+
+describe('updatePreferences', () => {
+  test('changes only the given fields', () => {
+    expect(updatePreferences(current, { remoteOnly: true, locations: ['Remote'] })).toEqual({
+      ...current,
+      remote_only: true,
+      preferred_locations: ['Remote'],
+    });
+  });
+
+  test('clears the minimum salary', () => {
+    expect(updatePreferences(current, { minSalary: null }).min_salary).toBeUndefined();
+  });
+
+  test('starts from defaults when nothing was saved', () => {
+    expect(updatePreferences(undefined, { jobTypes: ['contract'] })).toEqual({
+      remote_only: false,
+      preferred_locations: [],
+      excluded_companies: [],
+      job_types: ['contract'],
+    });
+  });
+});
+
+describe('describePreferences', () => {
+  test('formats every field', () => {
+    expect(describePreferences({ ...current, job_types: [] })).toEqual([
+      ['Remote only', 'No'],
+      ['Min salary', '$100,000'],
+      ['Locations', 'Berlin'],
+      ['Job types', 'any'],
+      ['Blocked companies', 'Initech'],
+    ]);
+  });
+});
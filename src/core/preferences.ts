@@ -0,0 +1,51 @@
+import { PreferencesSchema, type Preferences } from '../types';
+
+export interface PreferenceChanges {
+  remoteOnly?: boolean;
+  /** A number, or null to clear the minimum */
+  minSalary?: number | null;
+  locations?: string[];
+  jobTypes?: string[];
+}
+
+/** Split repeatable, comma-separated option values ("--location Berlin --location 'Remote, EU'"). */
+export function splitListOption(values: string[]): string[] {
+  return values.flatMap((value) => value.split(',')).map((value) => value.trim()).filter(Boolean);
+}
+
+/** Parse a --min-salary value; "none" or "0" clears it. Accepts "120,000" and "$120000". */
+export function parseSalaryOption(value: string): number | null {
+  const trimmed = value.trim().toLowerCase();
+  if (trimmed === 'none' || trimmed === '') return null;
+  const amount = Number(trimmed.replace(/[,_$]/g, ''));
+  if (!Number.isFinite(amount) || amount < 0) {
+    throw new Error(`Invalid --min-salary: ${value}`);
+  }
+  return amount === 0 ? null : amount;
+}
+
+/** Apply only the given changes, leaving every other preference as it was. */
+export function updatePreferences(current: Preferences | undefined, changes: PreferenceChanges): Preferences {
+  const next: Preferences = { ...PreferencesSchema.parse({}), ...current };
+
+  if (changes.remoteOnly !== undefined) next.remote_only = changes.remoteOnly;
+  if (changes.minSalary === null) delete next.min_salary;
+  else if (changes.minSalary !== undefined) next.min_salary = changes.minSalary;
+  if (changes.locations) next.preferred_locations = changes.locations;
+  if (changes.jobTypes) next.job_types = changes.jobTypes;
+
+  return PreferencesSchema.parse(next);
+}
+
+/** Label/value pairs for display; empty lists and an unset salary read as "any". */
+export function describePreferences(preferences: Preferences | undefined): Array<[string, string]> {
+  const prefs = { ...PreferencesSchema.parse({}), ...preferences };
+  const list = (items: string[]) => (items.length > 0 ? items.join(', ') : 'any');
+  return [
+    ['Remote only', prefs.remote_only ? 'Yes' : 'No'],
+    ['Min salary', prefs.min_salary ? `$${prefs.min_salary.toLocaleString('en-US')}` : 'any'],
+    ['Locations', list(prefs.preferred_locations)],
+    ['Job types', list(prefs.job_types)],
+    ['Blocked companies', list(prefs.excluded_companies)],
+  ];
+}
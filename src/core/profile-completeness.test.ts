@@ -0,0 +1,53 @@
+import { describe, expect, test } from 'bun:test';
+import { computeProfileCompleteness } from './profile-completeness';
+import type { Profile } from '../types';
+
+const complete: Profile = {
+  name: 'Ada Lovelace',
+  email: 'ada@example.com',
+  phone: '+44 20 7946 0000',
+  location: 'London, UK',
+  base_resume: 'Ada Lovelace\nEngineer',
+  skills: ['Go', 'TypeScript', 'SQL'],
+  experience: [{ title: 'Engineer', company: 'Acme', start_date: '2020', highlights: [] }],
+  education: [],
+};
+
+function missing(result: ReturnType<typeof computeProfileCompleteness>): string[] {
+  return result.checks.filter((check) => !check.done).map((check) => check.label);
+}
+
+describe('computeProfileCompleteness', () => {
+  test('weights add up to 100 for a complete profile', () => {
+    const result = computeProfileCompleteness(complete, { aiConfigured: true });
+    expect(result.percent).toBe(100);
+    expect(missing(result)).toEqual([]);
+  });
+
+  test('a bare profile from init only gets credit for contact details', () => {
+    const bare: Profile = { name: 'Ada', email: 'ada@example.com', skills: [], experience: [], education: [] };
+    const result = computeProfileCompleteness(bare, { aiConfigured: false });
+
+    expect(result.percent).toBe(15);
+    expect(missing(result)).toEqual([
+      'Phone',
+      'Location',
+      'At least 3 skills',
+      'Work experience',
+      'Base resume',
+      'AI provider',
+    ]);
+  });
+
+  test('needs three non-blank skills', () => {
+    const result = computeProfileCompleteness({ ...complete, skills: ['Go', 'SQL', ' '] }, { aiConfigured: true });
+    expect(result.percent).toBe(80);
+    expect(missing(result)).toEqual(['At least 3 skills']);
+  });
+
+  test('treats a blank resume and missing provider as missing', () => {
+    const result = computeProfileCompleteness({ ...complete, base_resume: '  ' }, { aiConfigured: false });
+    expect(result.percent).toBe(65);
+    expect(result.checks.find((check) => check.label === 'Base resume')?.nextStep).toContain('autoply import resume');
+  });
+});
@@ -0,0 +1,74 @@
+import type { Profile } from '../types';
+
+export interface CompletenessCheck {
+  label: string;
+  /** Share of the total score, in percent */
+  weight: number;
+  done: boolean;
+  /** What to run or do when the check isn't met */
+  nextStep: string;
+}
+
+export interface ProfileCompleteness {
+  percent: number;
+  checks: CompletenessCheck[];
+}
+
+export const MIN_SKILLS = 3;
+
+/**
+ * Score how much of the profile is filled in, weighted toward what matching
+ * and document generation lean on most: skills, experience and a resume.
+ */
+export function computeProfileCompleteness(
+  profile: Profile,
+  options: { aiConfigured: boolean }
+): ProfileCompleteness {
+  const checks: CompletenessCheck[] = [
+    {
+      label: 'Name and email',
+      weight: 15,
+      done: Boolean(profile.name.trim() && profile.email.trim()),
+      nextStep: 'Add your name and email with "autoply profile edit"',
+    },
+    {
+      label: 'Phone',
+      weight: 5,
+      done: Boolean(profile.phone?.trim()),
+      nextStep: 'Add a phone number with "autoply profile edit" (LinkedIn and Workday ask for one)',
+    },
+    {
+      label: 'Location',
+      weight: 5,
+      done: Boolean(profile.location?.trim()),
+      nextStep: 'Add your location with "autoply profile edit" so location fit can be scored',
+    },
+    {
+      label: `At least ${MIN_SKILLS} skills`,
+      weight: 20,
+      done: profile.skills.filter((skill) => skill.trim()).length >= MIN_SKILLS,
+      nextStep: `List at least ${MIN_SKILLS} skills with "autoply profile edit"`,
+    },
+    {
+      label: 'Work experience',
+      weight: 20,
+      done: profile.experience.length > 0,
+      nextStep: 'Add at least one role with "autoply profile edit"',
+    },
+    {
+      label: 'Base resume',
+      weight: 20,
+      done: Boolean(profile.base_resume?.trim()),
+      nextStep: 'Import your resume with "autoply import resume <file>"',
+    },
+    {
+      label: 'AI provider',
+      weight: 15,
+      done: options.aiConfigured,
+      nextStep: 'Configure an AI provider ("autoply config set ai.provider ...", then "autoply config test")',
+    },
+  ];
+
+  const percent = checks.reduce((sum, check) => sum + (check.done ? check.weight : 0), 0);
+  return { percent, checks };
+}
@@ -1,5 +1,5 @@
 import { describe, expect, test, beforeEach, afterEach } from 'bun:test';
-import { ApplicationQueue } from './queue';
+import { ApplicationQueue, selectUrlsToApply } from './queue';
 
 describe('ApplicationQueue', () => {
   let queue: ApplicationQueue;
@@ -322,6 +322,61 @@ describe('ApplicationQueue', () => {
       expect(info!.savedAt).toBeTruthy();
     });
 
+    test('getPersistedInfo counts failed items separately', () => {
+      const item = queue.add('https://example.com/job1');
+      queue.add('https://example.com/job2');
+      queue.updateStatus(item.id, 'failed', 'Timeout');
+
+      expect(queue.getPersistedInfo()).toMatchObject({ pending: 1, failed: 1 });
+    });
+
+    test('pruneCompleted keeps only unfinished and failed items on disk', () => {
+      const done = queue.add('https://example.com/job1');
+      const failed = queue.add('https://example.com/job2');
+      const pending = queue.add('https://example.com/job3');
+      const skipped = queue.add('https://example.com/job4');
+      queue.updateStatus(done.id, 'completed');
+      queue.updateStatus(failed.id, 'failed', 'Timeout');
+      queue.updateStatus(skipped.id, 'skipped', 'Company is on your blocklist');
+      queue.pruneCompleted();
+
+      const newQueue = new ApplicationQueue();
+      newQueue.load();
+      expect(newQueue.getAll().map((item) => item.id).sort()).toEqual([failed.id, pending.id].sort());
+
+      newQueue.clear();
+    });
+
+    test('requeueFailed resumes only the failures', () => {
+      const done = queue.add('https://example.com/job1');
+      const failed = queue.add('https://example.com/job2');
+      queue.updateStatus(done.id, 'completed');
+      queue.updateStatus(failed.id, 'failed', 'Timeout');
+      queue.pruneCompleted();
+
+      const newQueue = new ApplicationQueue();
+      newQueue.load();
+      expect(newQueue.requeueFailed().map((item) => item.url)).toEqual(['https://example.com/job2']);
+      expect(newQueue.getNext()?.id).toBe(failed.id);
+      expect(newQueue.getNext()?.error).toBeUndefined();
+      expect(newQueue.getPersistedInfo()).toMatchObject({ pending: 1, failed: 0 });
+      expect(newQueue.requeueFailed()).toEqual([]);
+
+      newQueue.clear();
+    });
+
+    test("requeueFailed leaves jobs skipped by the user's filters", () => {
+      const failed = queue.add('https://example.com/job1');
+      const skipped = queue.add('https://example.com/job2');
+      queue.updateStatus(failed.id, 'failed', 'Timeout');
+      queue.updateStatus(skipped.id, 'skipped', 'Fit score below threshold');
+
+      expect(queue.requeueFailed().map((item) => item.id)).toEqual([failed.id]);
+      expect(queue.getSkipped().map((item) => item.id)).toEqual([skipped.id]);
+
+      queue.clear();
+    });
+
     test('getPersistedInfo returns null when no file', () => {
       queue.deletePersisted();
       expect(queue.getPersistedInfo()).toBeNull();
@@ -340,3 +395,38 @@ describe('ApplicationQueue', () => {
     });
   });
 });
+
+describe('selectUrlsToApply', () => {
+  const applied = new Set(['https://jobs.lever.co/acme/1']);
+  const hasApplication = (url: string) => applied.has(url);
+
+  test('skips jobs that already have an application', () => {
+    const selection = selectUrlsToApply(
+      ['https://jobs.lever.co/acme/1', 'https://jobs.lever.co/acme/2'],
+      hasApplication
+    );
+
+    expect(selection.urls).toEqual(['https://jobs.lever.co/acme/2']);
+    expect(selection.skipped).toEqual([{ url: 'https://jobs.lever.co/acme/1', reason: 'Already applied' }]);
+    expect(selection.reapplying).toEqual([]);
+  });
+
+  test('force queues a second application for the same job', () => {
+    const selection = selectUrlsToApply(['https://jobs.lever.co/acme/1/?utm_source=x'], hasApplication, { force: true });
+
+    expect(selection.urls).toEqual(['https://jobs.lever.co/acme/1']);
+    expect(selection.reapplying).toEqual(['https://jobs.lever.co/acme/1']);
+    expect(selection.skipped).toEqual([]);
+  });
+
+  test('force still drops duplicates within the batch', () => {
+    const selection = selectUrlsToApply(
+      ['https://jobs.lever.co/acme/1', 'https://jobs.lever.co/acme/1#apply'],
+      hasApplication,
+      { force: true }
+    );
+
+    expect(selection.urls).toHaveLength(1);
+    expect(selection.skipped[0].reason).toBe('Duplicate in current batch');
+  });
+});
@@ -3,16 +3,57 @@ import { randomUUID } from 'crypto';
 import { join } from 'path';
 import { existsSync, readFileSync, writeFileSync, unlinkSync } from 'fs';
 import { getAutoplyDir, ensureAutoplyDir } from '../db';
+import { normalizeUrl } from '../utils/url-parser';
 
 const QUEUE_FILE = 'queue.json';
 
+export interface UrlSelection {
+  /** Normalized URLs to queue, in input order */
+  urls: string[];
+  skipped: { url: string; reason: string }[];
+  /** URLs queued even though an application exists (force) */
+  reapplying: string[];
+}
+
+/**
+ * Drop batch duplicates and jobs that already have an application. With
+ * force, existing applications don't block a new one; duplicates still do.
+ */
+export function selectUrlsToApply(
+  urls: string[],
+  hasApplication: (url: string) => boolean,
+  options: { force?: boolean } = {}
+): UrlSelection {
+  const seen = new Set<string>();
+  const selection: UrlSelection = { urls: [], skipped: [], reapplying: [] };
+
+  for (const url of urls) {
+    const normalized = normalizeUrl(url);
+    if (seen.has(normalized)) {
+      selection.skipped.push({ url, reason: 'Duplicate in current batch' });
+      continue;
+    }
+    seen.add(normalized);
+
+    if (hasApplication(normalized) || hasApplication(url)) {
+      if (!options.force) {
+        selection.skipped.push({ url, reason: 'Already applied' });
+        continue;
+      }
+      selection.reapplying.push(normalized);
+    }
+    selection.urls.push(normalized);
+  }
+  return selection;
+}
+
 export class ApplicationQueue {
   private items: Map<string, QueueItem> = new Map();
   private processing = false;
-  private persistPath: string;
 
-  constructor() {
-    this.persistPath = join(getAutoplyDir(), QUEUE_FILE);
+  // Resolved on use so a --data-dir set after import still applies
+  private get persistPath(): string {
+    return join(getAutoplyDir(), QUEUE_FILE);
   }
 
   add(url: string): QueueItem {
@@ -53,6 +94,10 @@ export class ApplicationQueue {
     return this.getAll().filter((item) => item.status === 'failed');
   }
 
+  getSkipped(): QueueItem[] {
+    return this.getAll().filter((item) => item.status === 'skipped');
+  }
+
   updateStatus(id: string, status: QueueItem['status'], error?: string): void {
     const item = this.items.get(id);
     if (item) {
@@ -81,6 +126,25 @@ export class ApplicationQueue {
     this.deletePersisted();
   }
 
+  /** Drop finished and skipped items, keeping pending and failed ones for --resume / --retry-failed. */
+  pruneCompleted(): void {
+    for (const [id, item] of this.items) {
+      if (item.status === 'completed' || item.status === 'skipped') this.items.delete(id);
+    }
+    this.persist();
+  }
+
+  /** Put failed items back in line (clearing their errors) and return them. */
+  requeueFailed(): QueueItem[] {
+    const failed = this.getFailed();
+    for (const item of failed) {
+      item.status = 'pending';
+      delete item.error;
+    }
+    if (failed.length > 0) this.persist();
+    return failed;
+  }
+
   size(): number {
     return this.items.size;
   }
@@ -174,7 +238,7 @@ export class ApplicationQueue {
     return existsSync(this.persistPath);
   }
 
-  getPersistedInfo(): { pending: number; savedAt: string } | null {
+  getPersistedInfo(): { pending: number; failed: number; savedAt: string } | null {
     try {
       if (!existsSync(this.persistPath)) return null;
 
@@ -186,7 +250,8 @@ export class ApplicationQueue {
       const pending = data.items.filter(
         ([_, item]: [string, QueueItem]) => item.status === 'pending' || item.status === 'processing'
       ).length;
-      return { pending, savedAt: data.savedAt };
+      const failed = data.items.filter(([_, item]: [string, QueueItem]) => item.status === 'failed').length;
+      return { pending, failed, savedAt: data.savedAt };
     } catch {
       return null;
     }
@@ -0,0 +1,46 @@
+import { describe, expect, test } from 'bun:test';
+import { checkApplicationCaps, formatCapHit } from './rate-cap';
+
+const NOW = new Date('2026-06-01T12:00:00Z');
+
+/** countSince over fixed submission times, like the applications table. */
+function counter(appliedAt: string[]): (since: Date) => number {
+  return (since) => appliedAt.filter((t) => new Date(t) >= since).length;
+}
+
+describe('checkApplicationCaps', () => {
+  test('allows anything when no caps are set', () => {
+    expect(checkApplicationCaps({}, () => 1000, NOW)).toBeNull();
+  });
+
+  test('allows one below the hourly cap and blocks at it', () => {
+    const times = ['2026-06-01T11:30:00Z', '2026-06-01T11:45:00Z'];
+    expect(checkApplicationCaps({ maxPerHour: 3 }, counter(times), NOW)).toBeNull();
+
+    const hit = checkApplicationCaps({ maxPerHour: 2 }, counter(times), NOW);
+    expect(hit).toMatchObject({ window: 'hour', limit: 2, count: 2 });
+  });
+
+  test('counts an application exactly at the window start', () => {
+    const times = ['2026-06-01T11:00:00Z', '2026-06-01T10:59:59Z'];
+    expect(checkApplicationCaps({ maxPerHour: 1 }, counter(times), NOW)?.count).toBe(1);
+    expect(checkApplicationCaps({ maxPerHour: 2 }, counter(times), NOW)).toBeNull();
+  });
+
+  test('applies the daily cap over 24 hours', () => {
+    const times = ['2026-05-31T13:00:00Z', '2026-06-01T09:00:00Z', '2026-05-31T11:00:00Z'];
+    const hit = checkApplicationCaps({ maxPerHour: 5, maxPerDay: 2 }, counter(times), NOW);
+    expect(hit).toMatchObject({ window: 'day', limit: 2, count: 2 });
+  });
+
+  test('ignores zero caps', () => {
+    expect(checkApplicationCaps({ maxPerDay: 0 }, () => 10, NOW)).toBeNull();
+  });
+});
+
+describe('formatCapHit', () => {
+  test('names the setting to change', () => {
+    const message = formatCapHit({ window: 'day', limit: 20, count: 20, windowStart: NOW });
+    expect(message).toBe('Reached the limit of 20 application(s) per day (20 so far, application.maxPerDay).');
+  });
+});
@@ -0,0 +1,43 @@
+import type { AppConfig } from '../types';
+
+export interface ApplicationCapHit {
+  window: 'hour' | 'day';
+  limit: number;
+  count: number;
+  /** Start of the window that was counted */
+  windowStart: Date;
+}
+
+const HOUR_MS = 60 * 60 * 1000;
+
+/**
+ * Check application.maxPerHour and maxPerDay against the submissions already
+ * in the database. Returns the first cap that's been reached, or null if another
+ * application is allowed. Counts come from the database so the caps hold
+ * across separate runs.
+ */
+export function checkApplicationCaps(
+  application: Pick<AppConfig['application'], 'maxPerHour' | 'maxPerDay'>,
+  countSince: (since: Date) => number,
+  now = new Date()
+): ApplicationCapHit | null {
+  const caps = [
+    { window: 'hour' as const, limit: application.maxPerHour, ms: HOUR_MS },
+    { window: 'day' as const, limit: application.maxPerDay, ms: 24 * HOUR_MS },
+  ];
+
+  for (const cap of caps) {
+    if (cap.limit === undefined || cap.limit <= 0) continue;
+    const windowStart = new Date(now.getTime() - cap.ms);
+    const count = countSince(windowStart);
+    if (count >= cap.limit) {
+      return { window: cap.window, limit: cap.limit, count, windowStart };
+    }
+  }
+  return null;
+}
+
+export function formatCapHit(hit: ApplicationCapHit): string {
+  const setting = hit.window === 'hour' ? 'application.maxPerHour' : 'application.maxPerDay';
+  return `Reached the limit of ${hit.limit} application(s) per ${hit.window} (${hit.count} so far, ${setting}).`;
+}
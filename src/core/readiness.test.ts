@@ -0,0 +1,67 @@
+import { describe, expect, test } from 'bun:test';
+import { validateReadyToApply } from './readiness';
+import type { Profile } from '../types';
+
+const profile: Profile = {
+  name: 'Ada Lovelace',
+  email: 'ada@example.com',
+  phone: '+44 20 7946 0000',
+  base_resume: 'Ada Lovelace\nEngineer',
+  skills: [],
+  experience: [],
+  education: [],
+};
+
+const LEVER_URL = 'https://jobs.lever.co/acme/1';
+const LINKEDIN_URL = 'https://www.linkedin.com/jobs/view/123';
+
+describe('validateReadyToApply', () => {
+  test('passes a complete profile', () => {
+    expect(validateReadyToApply(LINKEDIN_URL, profile)).toEqual([]);
+  });
+
+  test('flags an empty URL', () => {
+    expect(validateReadyToApply('  ', profile)).toEqual(['Job URL is empty']);
+  });
+
+  test('flags an invalid URL', () => {
+    expect(validateReadyToApply('ftp://jobs.example.com/1', profile)).toEqual([
+      'ftp://jobs.example.com/1: URL must use HTTP or HTTPS protocol',
+    ]);
+  });
+
+  test('flags a missing name', () => {
+    expect(validateReadyToApply(LEVER_URL, { ...profile, name: '' })).toEqual(['Profile name is empty']);
+  });
+
+  test('flags a missing or malformed email', () => {
+    expect(validateReadyToApply(LEVER_URL, { ...profile, email: '' })).toEqual(['Profile email is empty']);
+    expect(validateReadyToApply(LEVER_URL, { ...profile, email: 'ada-at-example' })).toEqual([
+      'Profile email looks invalid: ada-at-example',
+    ]);
+  });
+
+  test('requires a phone only where the platform asks for one', () => {
+    const noPhone = { ...profile, phone: undefined };
+
+    expect(validateReadyToApply(LINKEDIN_URL, noPhone)).toEqual(['Profile phone is empty (required by linkedin)']);
+    expect(validateReadyToApply(LEVER_URL, noPhone)).toEqual([]);
+  });
+
+  test('flags a profile with nothing to build a resume from', () => {
+    expect(validateReadyToApply(LEVER_URL, { ...profile, base_resume: undefined })).toEqual([
+      'Profile has no resume or work experience to tailor from',
+    ]);
+    expect(
+      validateReadyToApply(LEVER_URL, {
+        ...profile,
+        base_resume: undefined,
+        experience: [{ company: 'Acme', title: 'Engineer', start_date: '2020-01', highlights: [] }],
+      })
+    ).toEqual([]);
+  });
+
+  test('reports every problem at once', () => {
+    expect(validateReadyToApply(LINKEDIN_URL, { ...profile, name: '', phone: '' })).toHaveLength(2);
+  });
+});
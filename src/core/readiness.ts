@@ -0,0 +1,43 @@
+import type { Platform, Profile } from '../types';
+import { parseJobUrl } from '../utils/url-parser';
+
+/** Platforms whose application forms always ask for a phone number */
+export const PHONE_REQUIRED_PLATFORMS: readonly Platform[] = [
+  'linkedin',
+  'greenhouse',
+  'workday',
+  'smartrecruiters',
+  'bamboohr',
+];
+
+/**
+ * Problems that would make an automated application fail partway through
+ * the browser run. An empty list means the job is ready to apply to.
+ */
+export function validateReadyToApply(url: string, profile: Profile): string[] {
+  const issues: string[] = [];
+
+  if (!url.trim()) {
+    issues.push('Job URL is empty');
+  } else {
+    const parsed = parseJobUrl(url.trim());
+    if (!parsed.isValid) {
+      issues.push(`${url}: ${parsed.error ?? 'invalid URL'}`);
+    } else if (PHONE_REQUIRED_PLATFORMS.includes(parsed.platform) && !profile.phone?.trim()) {
+      issues.push(`Profile phone is empty (required by ${parsed.platform})`);
+    }
+  }
+
+  if (!profile.name?.trim()) issues.push('Profile name is empty');
+  if (!profile.email?.trim()) {
+    issues.push('Profile email is empty');
+  } else if (!/^[^\s@]+@[^\s@]+\.[^\s@]+$/.test(profile.email.trim())) {
+    issues.push(`Profile email looks invalid: ${profile.email}`);
+  }
+
+  if (!profile.base_resume?.trim() && profile.experience.length === 0) {
+    issues.push('Profile has no resume or work experience to tailor from');
+  }
+
+  return issues;
+}
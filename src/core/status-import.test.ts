@@ -0,0 +1,69 @@
+import { describe, expect, test, beforeEach } from 'bun:test';
+import { createTestProfile, useTempDataDir } from '../db/test-helpers';
+import { applicationRepository } from '../db/repositories/application';
+import { importStatuses } from './status-import';
+
+const NOW = new Date('2026-03-01T12:00:00Z');
+
+let ids: number[];
+
+useTempDataDir();
+
+beforeEach(() => {
+  const profileId = createTestProfile().id!;
+  ids = ['Acme', 'Globex'].map(
+    (company, i) =>
+      applicationRepository.create({
+        profile_id: profileId,
+        url: `https://jobs.lever.co/${company.toLowerCase()}/${i}`,
+        platform: 'lever',
+        company,
+        job_title: 'Engineer',
+        status: 'pending',
+      }).id!
+  );
+});
+
+describe('importStatuses', () => {
+  test('updates matching applications and reports bad rows without stopping', () => {
+    const csv = [
+      'job_id,status,notes',
+      `${ids[0]},Submitted,"Recruiter replied, call Friday"`,
+      `${ids[1]},ghosted,`,
+      '999,failed,',
+    ].join('\n');
+
+    const rows = importStatuses(csv, NOW);
+
+    expect(rows.map((r) => [r.line, r.updated])).toEqual([
+      [2, true],
+      [3, false],
+      [4, false],
+    ]);
+    expect(rows[1].message).toContain('Invalid status "ghosted"');
+    expect(rows[2].message).toBe('Application #999 not found');
+
+    const acme = applicationRepository.findById(ids[0])!;
+    expect(acme.status).toBe('submitted');
+    expect(acme.applied_at).toBe(NOW.toISOString());
+    expect(acme.notes).toBe('[2026-03-01 12:00] Recruiter replied, call Friday');
+    expect(applicationRepository.findById(ids[1])!.status).toBe('pending');
+  });
+
+  test('accepts an id column and leaves notes alone when the cell is empty', () => {
+    applicationRepository.update(ids[0], { notes: 'Keep me' });
+
+    const [row] = importStatuses(`id,status\n#${ids[0]},failed\n`, NOW);
+
+    expect(row.updated).toBe(true);
+    const app = applicationRepository.findById(ids[0])!;
+    expect(app.status).toBe('failed');
+    expect(app.notes).toBe('Keep me');
+    expect(app.applied_at).toBeUndefined();
+  });
+
+  test('rejects a file without the required columns', () => {
+    expect(() => importStatuses('url,status\nhttps://example.com,failed\n')).toThrow('id (or job_id)');
+    expect(() => importStatuses(`id,state\n${ids[0]},failed\n`)).toThrow('status column');
+  });
+});
@@ -0,0 +1,62 @@
+import type { ApplicationStatus } from '../types';
+import { applicationRepository } from '../db/repositories/application';
+import { parseCsvRecords } from '../utils/csv';
+import { parseApplicationId } from './history-view';
+
+const STATUSES: ApplicationStatus[] = ['pending', 'submitted', 'failed'];
+
+export interface StatusImportRow {
+  /** 1-based line number in the file, counting the header */
+  line: number;
+  id?: number;
+  updated: boolean;
+  message: string;
+}
+
+/**
+ * Apply a CSV of status changes with columns id (or job_id), status and an
+ * optional notes column. Notes are added as a timestamped line rather than
+ * replacing what's there. Bad rows are reported and skipped; the rest still
+ * apply.
+ */
+export function importStatuses(content: string, now = new Date()): StatusImportRow[] {
+  const records = parseCsvRecords(content);
+  if (records.length > 0 && !('id' in records[0] || 'job_id' in records[0])) {
+    throw new Error('CSV needs an id (or job_id) column');
+  }
+  if (records.length > 0 && !('status' in records[0])) {
+    throw new Error('CSV needs a status column');
+  }
+
+  return records.map((record, index) => {
+    const line = index + 2;
+
+    let id: number;
+    try {
+      id = parseApplicationId(record.id || record.job_id || '');
+    } catch (error) {
+      return { line, updated: false, message: error instanceof Error ? error.message : String(error) };
+    }
+
+    const status = record.status.toLowerCase() as ApplicationStatus;
+    if (!STATUSES.includes(status)) {
+      return { line, id, updated: false, message: `Invalid status "${record.status}". Use: ${STATUSES.join(', ')}` };
+    }
+
+    const existing = applicationRepository.findById(id);
+    if (!existing) {
+      return { line, id, updated: false, message: `Application #${id} not found` };
+    }
+
+    applicationRepository.update(id, {
+      status,
+      applied_at: status === 'submitted' && !existing.applied_at ? now.toISOString() : undefined,
+    });
+    if (record.notes) {
+      applicationRepository.appendNotes(id, record.notes, now);
+    }
+
+    const change = existing.status === status ? `still ${status}` : `${existing.status} → ${status}`;
+    return { line, id, updated: true, message: `${existing.job_title} at ${existing.company}: ${change}` };
+  });
+}
@@ -0,0 +1,46 @@
+import { describe, expect, test, afterEach } from 'bun:test';
+import { homedir } from 'os';
+import { join } from 'path';
+import { getAutoplyDir, getDbPath, setAutoplyDir } from './index';
+import { getConfigPath } from './repositories/config';
+import { getAILogPath } from '../ai/debug-log';
+
+const originalHome = process.env.AUTOPLY_HOME;
+
+afterEach(() => {
+  setAutoplyDir(null);
+  if (originalHome === undefined) {
+    delete process.env.AUTOPLY_HOME;
+  } else {
+    process.env.AUTOPLY_HOME = originalHome;
+  }
+});
+
+describe('data directory', () => {
+  test('defaults to ~/.autoply', () => {
+    delete process.env.AUTOPLY_HOME;
+    expect(getAutoplyDir()).toBe(join(homedir(), '.autoply'));
+  });
+
+  test('AUTOPLY_HOME redirects every data path', () => {
+    process.env.AUTOPLY_HOME = '/tmp/autoply-test-home';
+
+    expect(getAutoplyDir()).toBe('/tmp/autoply-test-home');
+    expect(getDbPath()).toBe('/tmp/autoply-test-home/autoply.db');
+    expect(getConfigPath()).toBe('/tmp/autoply-test-home/config.json');
+    expect(getAILogPath()).toBe('/tmp/autoply-test-home/logs/ai.log');
+  });
+
+  test('expands ~ in AUTOPLY_HOME', () => {
+    process.env.AUTOPLY_HOME = '~/jobs/autoply';
+    expect(getAutoplyDir()).toBe(join(homedir(), 'jobs/autoply'));
+  });
+
+  test('--data-dir takes precedence over AUTOPLY_HOME', () => {
+    process.env.AUTOPLY_HOME = '/tmp/autoply-test-home';
+    setAutoplyDir('/tmp/autoply-override');
+
+    expect(getAutoplyDir()).toBe('/tmp/autoply-override');
+    expect(getConfigPath()).toBe('/tmp/autoply-override/config.json');
+  });
+});
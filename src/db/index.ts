@@ -1,31 +1,46 @@
 import { Database } from 'bun:sqlite';
 import { homedir } from 'os';
-import { join } from 'path';
+import { join, resolve } from 'path';
 import { mkdirSync, existsSync } from 'fs';
 
-const AUTOPLY_DIR = join(homedir(), '.autoply');
-const DB_PATH = join(AUTOPLY_DIR, 'autoply.db');
-
+let dataDirOverride: string | null = null;
 let db: Database | null = null;
 
-export function getDbPath(): string {
-  return DB_PATH;
+export function expandHome(path: string): string {
+  return path === '~' || path.startsWith('~/') ? join(homedir(), path.slice(1)) : path;
+}
+
+/**
+ * Point all data (database, config, documents, screenshots) at another
+ * directory. Takes precedence over AUTOPLY_HOME. Pass null to reset.
+ */
+export function setAutoplyDir(dir: string | null): void {
+  closeDb();
+  dataDirOverride = dir ? resolve(expandHome(dir)) : null;
 }
 
 export function getAutoplyDir(): string {
-  return AUTOPLY_DIR;
+  if (dataDirOverride) return dataDirOverride;
+  const fromEnv = process.env.AUTOPLY_HOME?.trim();
+  if (fromEnv) return resolve(expandHome(fromEnv));
+  return join(homedir(), '.autoply');
+}
+
+export function getDbPath(): string {
+  return join(getAutoplyDir(), 'autoply.db');
 }
 
 export function ensureAutoplyDir(): void {
-  if (!existsSync(AUTOPLY_DIR)) {
-    mkdirSync(AUTOPLY_DIR, { recursive: true });
+  const dir = getAutoplyDir();
+  if (!existsSync(dir)) {
+    mkdirSync(dir, { recursive: true });
   }
 }
 
 export function getDb(): Database {
   if (!db) {
     ensureAutoplyDir();
-    db = new Database(DB_PATH);
+    db = new Database(getDbPath());
     db.exec('PRAGMA journal_mode = WAL');
     db.exec('PRAGMA foreign_keys = ON');
     runMigrations(db);
@@ -104,6 +119,62 @@ function runMigrations(database: Database): void {
         )
       `,
     },
+    {
+      name: '004_create_job_cache',
+      sql: `
+        CREATE TABLE IF NOT EXISTS job_cache (
+          url TEXT PRIMARY KEY,
+          platform TEXT NOT NULL,
+          job_data TEXT NOT NULL,
+          cached_at INTEGER NOT NULL
+        )
+      `,
+    },
+    {
+      name: '005_add_apply_method',
+      sql: `ALTER TABLE applications ADD COLUMN apply_method TEXT`,
+    },
+    {
+      name: '006_create_generated_documents',
+      sql: `
+        CREATE TABLE IF NOT EXISTS generated_documents (
+          id INTEGER PRIMARY KEY AUTOINCREMENT,
+          url TEXT NOT NULL,
+          company TEXT NOT NULL,
+          job_title TEXT NOT NULL,
+          type TEXT NOT NULL,
+          content TEXT NOT NULL,
+          created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+        )
+      `,
+    },
+    {
+      name: '007_add_application_notes',
+      sql: `ALTER TABLE applications ADD COLUMN notes TEXT`,
+    },
+    {
+      name: '008_add_document_word_count',
+      sql: `ALTER TABLE generated_documents ADD COLUMN word_count INTEGER`,
+    },
+    {
+      name: '009_add_document_char_count',
+      sql: `ALTER TABLE generated_documents ADD COLUMN char_count INTEGER`,
+    },
+    {
+      name: '010_create_application_tags',
+      sql: `
+        CREATE TABLE IF NOT EXISTS application_tags (
+          application_id INTEGER NOT NULL,
+          tag TEXT NOT NULL,
+          PRIMARY KEY (application_id, tag),
+          FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE CASCADE
+        )
+      `,
+    },
+    {
+      name: '011_add_application_base_resume_source',
+      sql: `ALTER TABLE applications ADD COLUMN base_resume_source TEXT`,
+    },
   ];
 
   const appliedMigrations = database
@@ -0,0 +1,119 @@
+import { describe, expect, test, beforeEach } from 'bun:test';
+import { getDb } from './index';
+import { checkDatabase, isHealthy, pruneStaleData, repairDatabase } from './maintenance';
+import { jobCacheRepository } from './repositories/job-cache';
+import { applicationRepository } from './repositories/application';
+import { createTestProfile, useTempDataDir } from './test-helpers';
+
+useTempDataDir();
+
+function seedOrphan(): void {
+  const db = getDb();
+  db.exec('PRAGMA foreign_keys = OFF');
+  db.run(
+    `INSERT INTO applications (profile_id, url, platform, company, job_title, status)
+     VALUES (999, 'https://jobs.lever.co/acme/1', 'lever', 'Acme', 'Engineer', 'failed')`
+  );
+  db.exec('PRAGMA foreign_keys = ON');
+}
+
+describe('checkDatabase', () => {
+  test('a fresh database is healthy', () => {
+    expect(isHealthy(checkDatabase())).toBe(true);
+  });
+
+  test('reports applications whose profile is gone', () => {
+    seedOrphan();
+
+    const check = checkDatabase();
+
+    expect(check.integrityErrors).toEqual([]);
+    expect(check.foreignKeyViolations).toHaveLength(1);
+    expect(check.foreignKeyViolations[0]).toMatchObject({ table: 'applications', parent: 'profiles' });
+  });
+});
+
+describe('repairDatabase', () => {
+  test('removes orphaned rows and keeps valid ones', () => {
+    const profile = createTestProfile();
+    applicationRepository.create({
+      profile_id: profile.id!,
+      url: 'https://jobs.lever.co/acme/2',
+      platform: 'lever',
+      company: 'Acme',
+      job_title: 'Designer',
+      status: 'pending',
+    });
+    seedOrphan();
+
+    expect(repairDatabase()).toBe(1);
+    expect(isHealthy(checkDatabase())).toBe(true);
+    expect(applicationRepository.count()).toBe(1);
+  });
+});
+
+describe('pruneStaleData', () => {
+  const NOW = Date.UTC(2026, 5, 1);
+  const DAY = 24 * 60 * 60_000;
+  const OLD_URL = 'https://jobs.lever.co/acme/old';
+  const APPLIED_URL = 'https://jobs.lever.co/acme/applied';
+  const NEW_URL = 'https://jobs.lever.co/acme/new';
+
+  function job(url: string) {
+    return {
+      url,
+      platform: 'lever' as const,
+      title: 'Engineer',
+      company: 'Acme',
+      description: '',
+      requirements: [],
+      qualifications: [],
+      form_fields: [],
+      custom_questions: [],
+    };
+  }
+
+  function seedDocument(url: string, createdAt: string): void {
+    getDb().run(
+      `INSERT INTO generated_documents (url, company, job_title, type, content, created_at)
+       VALUES (?, 'Acme', 'Engineer', 'cover-letter', 'Dear Acme', ?)`,
+      [url, createdAt]
+    );
+  }
+
+  beforeEach(() => {
+    const profile = createTestProfile();
+    applicationRepository.create({
+      profile_id: profile.id!,
+      url: APPLIED_URL,
+      platform: 'lever',
+      company: 'Acme',
+      job_title: 'Engineer',
+      status: 'submitted',
+    });
+
+    jobCacheRepository.save(OLD_URL, 'lever', job(OLD_URL), NOW - 60 * DAY);
+    jobCacheRepository.save(APPLIED_URL, 'lever', job(APPLIED_URL), NOW - 60 * DAY);
+    jobCacheRepository.save(NEW_URL, 'lever', job(NEW_URL), NOW - DAY);
+    seedDocument(OLD_URL, '2026-03-01 12:00:00');
+    seedDocument(APPLIED_URL, '2026-03-01 12:00:00');
+    seedDocument(NEW_URL, '2026-05-31 12:00:00');
+  });
+
+  test('dry run counts stale, unapplied rows without deleting', () => {
+    const before = new Date(NOW - 30 * DAY);
+
+    expect(pruneStaleData(before, { dryRun: true })).toEqual({ cachedJobs: 1, documents: 1 });
+    expect(pruneStaleData(before, { dryRun: true })).toEqual({ cachedJobs: 1, documents: 1 });
+  });
+
+  test('deletes only stale rows with no application', () => {
+    expect(pruneStaleData(new Date(NOW - 30 * DAY))).toEqual({ cachedJobs: 1, documents: 1 });
+
+    const cached = getDb().query<{ url: string }, []>('SELECT url FROM job_cache ORDER BY url').all();
+    expect(cached.map((row) => row.url)).toEqual([APPLIED_URL, NEW_URL]);
+    const docs = getDb().query<{ url: string }, []>('SELECT url FROM generated_documents ORDER BY url').all();
+    expect(docs.map((row) => row.url)).toEqual([APPLIED_URL, NEW_URL]);
+  });
+});
+
@@ -0,0 +1,88 @@
+import type { Database } from 'bun:sqlite';
+import { getDb } from './index';
+
+export interface ForeignKeyViolation {
+  table: string;
+  rowid: number;
+  parent: string;
+}
+
+export interface DatabaseCheck {
+  /** Messages from PRAGMA integrity_check; empty when the file is sound */
+  integrityErrors: string[];
+  foreignKeyViolations: ForeignKeyViolation[];
+}
+
+export function isHealthy(check: DatabaseCheck): boolean {
+  return check.integrityErrors.length === 0 && check.foreignKeyViolations.length === 0;
+}
+
+export function checkDatabase(db: Database = getDb()): DatabaseCheck {
+  const integrityErrors = db
+    .query<{ integrity_check: string }, []>('PRAGMA integrity_check')
+    .all()
+    .map((row) => row.integrity_check)
+    .filter((message) => message !== 'ok');
+
+  const foreignKeyViolations = db
+    .query<{ table: string; rowid: number; parent: string }, []>('PRAGMA foreign_key_check')
+    .all()
+    .map(({ table, rowid, parent }) => ({ table, rowid, parent }));
+
+  return { integrityErrors, foreignKeyViolations };
+}
+
+/**
+ * Delete rows whose parent no longer exists (e.g. applications left behind
+ * when a profile was removed with foreign keys off) and make sure foreign
+ * keys are enforced again. Corruption reported by integrity_check can't be
+ * fixed here. Returns the number of rows removed.
+ */
+export function repairDatabase(db: Database = getDb()): number {
+  const { foreignKeyViolations } = checkDatabase(db);
+
+  const removeOrphans = db.transaction((violations: ForeignKeyViolation[]) => {
+    let removed = 0;
+    for (const { table, rowid } of violations) {
+      // Table names come from SQLite itself, not user input
+      removed += db.run(`DELETE FROM "${table.replace(/"/g, '""')}" WHERE rowid = ?`, [rowid]).changes;
+    }
+    return removed;
+  });
+
+  const removed = removeOrphans(foreignKeyViolations);
+  db.exec('PRAGMA foreign_keys = ON');
+  return removed;
+}
+
+export interface StaleData {
+  cachedJobs: number;
+  documents: number;
+}
+
+function sqliteTimestamp(date: Date): string {
+  return date.toISOString().slice(0, 19).replace('T', ' ');
+}
+
+/**
+ * Remove scraped jobs and generated documents older than `before` that never
+ * turned into an application. With dryRun, only count them.
+ */
+export function pruneStaleData(before: Date, options: { dryRun?: boolean } = {}, db: Database = getDb()): StaleData {
+  const cacheWhere = 'FROM job_cache WHERE cached_at < ? AND url NOT IN (SELECT url FROM applications)';
+  const documentWhere = 'FROM generated_documents WHERE created_at < ? AND url NOT IN (SELECT url FROM applications)';
+  const cacheParams = [before.getTime()];
+  const documentParams = [sqliteTimestamp(before)];
+
+  if (options.dryRun) {
+    const count = (sql: string, params: Array<string | number>) =>
+      db.query<{ count: number }, Array<string | number>>(`SELECT COUNT(*) AS count ${sql}`).get(...params)?.count ?? 0;
+    return { cachedJobs: count(cacheWhere, cacheParams), documents: count(documentWhere, documentParams) };
+  }
+
+  return db.transaction(() => ({
+    cachedJobs: db.run(`DELETE ${cacheWhere}`, cacheParams).changes,
+    documents: db.run(`DELETE ${documentWhere}`, documentParams).changes,
+  }))();
+}
+
@@ -0,0 +1,244 @@
+import { describe, expect, test, beforeEach } from 'bun:test';
+import { createTestProfile, useTempDataDir } from '../test-helpers';
+import { applicationRepository, normalizeTag } from './application';
+
+const URL = 'https://jobs.lever.co/acme/1';
+
+let profileId: number;
+
+useTempDataDir();
+
+beforeEach(() => {
+  profileId = createTestProfile().id!;
+});
+
+function createApplication(created_at: string) {
+  return applicationRepository.create({
+    profile_id: profileId,
+    url: URL,
+    platform: 'lever',
+    company: 'Acme',
+    job_title: 'Engineer',
+    status: 'submitted',
+    created_at,
+  });
+}
+
+describe('multiple applications per job', () => {
+  test('findByUrl lists every application, newest first', () => {
+    const first = createApplication('2026-01-01 10:00:00');
+    const second = createApplication('2026-06-01 10:00:00');
+
+    expect(applicationRepository.findByUrl(URL).map((app) => app.id)).toEqual([second.id, first.id]);
+    expect(applicationRepository.existsByUrl(URL)).toBe(true);
+  });
+});
+
+describe('findLatest', () => {
+  test('returns the newest application and moves back after a delete', () => {
+    const older = createApplication('2026-01-01 10:00:00');
+    const newer = createApplication('2026-06-01 10:00:00');
+
+    expect(applicationRepository.findLatest()?.id).toBe(newer.id);
+
+    expect(applicationRepository.delete(newer.id!)).toBe(true);
+    expect(applicationRepository.findLatest()?.id).toBe(older.id);
+  });
+
+  test('is null with no applications', () => {
+    expect(applicationRepository.findLatest()).toBeNull();
+  });
+});
+
+describe('base resume source', () => {
+  test('records which resume an application was tailored from', () => {
+    const fromProfile = createApplication('2026-06-01 10:00:00');
+    const fromFile = applicationRepository.create({
+      profile_id: profileId,
+      url: URL,
+      platform: 'lever',
+      company: 'Acme',
+      job_title: 'Engineer',
+      status: 'pending',
+      base_resume_source: '/home/ada/backend.md',
+    });
+
+    expect(fromProfile.base_resume_source).toBeUndefined();
+    expect(applicationRepository.findById(fromFile.id!)?.base_resume_source).toBe('/home/ada/backend.md');
+    expect(applicationRepository.update(fromProfile.id!, { base_resume_source: 'profile' })?.base_resume_source).toBe('profile');
+  });
+});
+
+describe('createOrRetry', () => {
+  const attempt = {
+    url: URL,
+    platform: 'lever' as const,
+    company: 'Acme',
+    job_title: 'Engineer',
+    status: 'pending' as const,
+    generated_resume: 'second try',
+  };
+
+  test('updates the failed application being retried', () => {
+    const failed = applicationRepository.create({ ...attempt, profile_id: profileId, status: 'failed', error_message: 'Timeout' });
+
+    const retried = applicationRepository.createOrRetry(failed.id, { ...attempt, profile_id: profileId });
+
+    expect(retried.id).toBe(failed.id);
+    expect(retried.status).toBe('pending');
+    expect(retried.generated_resume).toBe('second try');
+    expect(retried.error_message).toBeFalsy();
+    expect(applicationRepository.findAll()).toHaveLength(1);
+  });
+
+  test('creates a new record when there is nothing failed to reuse', () => {
+    const submitted = applicationRepository.create({ ...attempt, profile_id: profileId, status: 'submitted' });
+
+    expect(applicationRepository.createOrRetry(undefined, { ...attempt, profile_id: profileId }).id).not.toBe(submitted.id);
+    expect(applicationRepository.createOrRetry(submitted.id, { ...attempt, profile_id: profileId }).id).not.toBe(submitted.id);
+    expect(applicationRepository.findAll()).toHaveLength(3);
+  });
+});
+
+describe('countSubmittedSince', () => {
+  function createWithStatus(status: 'pending' | 'submitted' | 'failed', applied_at?: string) {
+    return applicationRepository.create({
+      profile_id: profileId,
+      url: URL,
+      platform: 'lever',
+      company: 'Acme',
+      job_title: 'Engineer',
+      status,
+      applied_at,
+      created_at: '2026-06-01 10:30:00',
+    });
+  }
+
+  test('counts submissions at or after the given time', () => {
+    createWithStatus('submitted', '2026-06-01T09:59:59.000Z');
+    createWithStatus('submitted', '2026-06-01T10:00:00.000Z');
+    createWithStatus('submitted', '2026-06-01 11:30:00');
+
+    expect(applicationRepository.countSubmittedSince(new Date('2026-06-01T10:00:00Z'))).toBe(2);
+    expect(applicationRepository.countSubmittedSince(new Date('2026-06-01T12:00:00Z'))).toBe(0);
+  });
+
+  test('ignores failed and pending records inside the window', () => {
+    createWithStatus('submitted', '2026-06-01T10:15:00.000Z');
+    createWithStatus('failed');
+    createWithStatus('pending');
+    createWithStatus('failed', '2026-06-01T10:20:00.000Z');
+
+    expect(applicationRepository.countSubmittedSince(new Date('2026-06-01T10:00:00Z'))).toBe(1);
+  });
+});
+
+describe('apply method', () => {
+  function createWithMethod(apply_method: 'auto' | 'manual' | undefined, status: 'submitted' | 'failed') {
+    return applicationRepository.create({
+      profile_id: profileId,
+      url: URL,
+      platform: 'lever',
+      company: 'Acme',
+      job_title: 'Engineer',
+      status,
+      apply_method,
+    });
+  }
+
+  test('round-trips through create and update', () => {
+    const app = createWithMethod('manual', 'submitted');
+    expect(app.apply_method).toBe('manual');
+
+    expect(applicationRepository.update(app.id!, { apply_method: 'referral' })?.apply_method).toBe('referral');
+  });
+
+  test('counts applications by method', () => {
+    createWithMethod('auto', 'submitted');
+    createWithMethod('auto', 'failed');
+    createWithMethod('auto', 'submitted');
+    createWithMethod('manual', 'submitted');
+    createWithMethod(undefined, 'submitted');
+
+    expect(applicationRepository.countByMethod()).toEqual([
+      { method: 'auto', total: 3, submitted: 2, failed: 1 },
+      { method: 'manual', total: 1, submitted: 1, failed: 0 },
+      { method: 'unknown', total: 1, submitted: 1, failed: 0 },
+    ]);
+  });
+});
+
+describe('notes', () => {
+  test('append keeps earlier notes, newest first, with timestamps', () => {
+    const app = createApplication('2026-01-01 09:00:00');
+
+    applicationRepository.appendNotes(app.id!, 'Recruiter call booked', new Date('2026-03-02T10:15:00Z'));
+    const updated = applicationRepository.appendNotes(app.id!, '  Onsite next week ', new Date('2026-03-09T16:40:00Z'));
+
+    expect(updated?.notes).toBe('[2026-03-09 16:40] Onsite next week\n[2026-03-02 10:15] Recruiter call booked');
+  });
+
+  test('update still overwrites', () => {
+    const app = createApplication('2026-01-01 09:00:00');
+    applicationRepository.appendNotes(app.id!, 'First');
+
+    expect(applicationRepository.update(app.id!, { notes: 'Replaced' })?.notes).toBe('Replaced');
+  });
+
+  test('append returns null for a missing application', () => {
+    expect(applicationRepository.appendNotes(9999, 'note')).toBeNull();
+  });
+});
+
+describe('tags', () => {
+  test('normalizes tags to lowercase single words', () => {
+    expect(normalizeTag('  Dream Job ')).toBe('dream-job');
+  });
+
+  test('adds, lists and removes tags without duplicates', () => {
+    const app = createApplication('2026-06-01 10:00:00');
+
+    expect(applicationRepository.addTags(app.id!, ['Dream', 'backup', 'dream'])).toEqual(['backup', 'dream']);
+    expect(applicationRepository.removeTags(app.id!, ['BACKUP', 'missing'])).toEqual(['dream']);
+    expect(applicationRepository.getTags(app.id!)).toEqual(['dream']);
+  });
+
+  test('returns null for an unknown application', () => {
+    expect(applicationRepository.addTags(999, ['dream'])).toBeNull();
+    expect(applicationRepository.removeTags(999, ['dream'])).toBeNull();
+  });
+
+  test('filters applications by tag and counts tag usage', () => {
+    const first = createApplication('2026-01-01 10:00:00');
+    const second = createApplication('2026-06-01 10:00:00');
+    createApplication('2026-07-01 10:00:00');
+    applicationRepository.addTags(first.id!, ['dream']);
+    applicationRepository.addTags(second.id!, ['dream', 'backup']);
+
+    expect(applicationRepository.findAll({ tag: 'Dream' }).map((app) => app.id)).toEqual([second.id, first.id]);
+    expect(applicationRepository.countTags()).toEqual([
+      { tag: 'dream', count: 2 },
+      { tag: 'backup', count: 1 },
+    ]);
+  });
+
+  test('copies tags onto another application so they survive a delete', () => {
+    const survivor = createApplication('2026-06-01 10:00:00');
+    const duplicate = createApplication('2026-06-02 10:00:00');
+    applicationRepository.addTags(survivor.id!, ['dream']);
+    applicationRepository.addTags(duplicate.id!, ['dream', 'referral']);
+
+    expect(applicationRepository.copyTags(duplicate.id!, survivor.id!)).toEqual(['dream', 'referral']);
+    applicationRepository.delete(duplicate.id!);
+    expect(applicationRepository.getTags(survivor.id!)).toEqual(['dream', 'referral']);
+  });
+
+  test('deleting an application drops its tags', () => {
+    const app = createApplication('2026-06-01 10:00:00');
+    applicationRepository.addTags(app.id!, ['dream']);
+    applicationRepository.delete(app.id!);
+
+    expect(applicationRepository.countTags()).toEqual([]);
+  });
+});
+
@@ -1,5 +1,5 @@
 import { getDb } from '../index';
-import type { Application, ApplicationStatus, Platform } from '../../types';
+import type { Application, ApplicationStatus, ApplyMethod, Platform } from '../../types';
 import type { SQLQueryBindings } from 'bun:sqlite';
 
 export interface ApplicationRow {
@@ -10,14 +10,24 @@ export interface ApplicationRow {
   company: string;
   job_title: string;
   status: string;
+  apply_method: string | null;
   generated_resume: string | null;
   generated_cover_letter: string | null;
   form_data: string | null;
   error_message: string | null;
+  notes: string | null;
+  base_resume_source: string | null;
   applied_at: string | null;
   created_at: string;
 }
 
+export interface MethodStats {
+  method: ApplyMethod | 'unknown';
+  total: number;
+  submitted: number;
+  failed: number;
+}
+
 function rowToApplication(row: ApplicationRow): Application {
   return {
     id: row.id,
@@ -27,23 +37,32 @@ function rowToApplication(row: ApplicationRow): Application {
     company: row.company,
     job_title: row.job_title,
     status: row.status as ApplicationStatus,
+    apply_method: (row.apply_method as ApplyMethod | null) ?? undefined,
     generated_resume: row.generated_resume ?? undefined,
     generated_cover_letter: row.generated_cover_letter ?? undefined,
     form_data: row.form_data ? (() => { try { return JSON.parse(row.form_data!); } catch { return undefined; } })() : undefined,
     error_message: row.error_message ?? undefined,
+    notes: row.notes ?? undefined,
+    base_resume_source: row.base_resume_source ?? undefined,
     applied_at: row.applied_at ?? undefined,
     created_at: row.created_at,
   };
 }
 
+/** Tags are case-insensitive single words: "Dream Job" becomes "dream-job". */
+export function normalizeTag(tag: string): string {
+  return tag.trim().toLowerCase().replace(/\s+/g, '-');
+}
+
 export class ApplicationRepository {
-  create(application: Omit<Application, 'id' | 'created_at'>): Application {
+  /** created_at defaults to now; pass it to keep the original date (e.g. when importing) */
+  create(application: Omit<Application, 'id'>): Application {
     const db = getDb();
     const stmt = db.prepare(`
       INSERT INTO applications (
-        profile_id, url, platform, company, job_title, status,
-        generated_resume, generated_cover_letter, form_data, error_message, applied_at
-      ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        profile_id, url, platform, company, job_title, status, apply_method,
+        generated_resume, generated_cover_letter, form_data, error_message, notes, base_resume_source, applied_at, created_at
+      ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP))
     `);
 
     const result = stmt.run(
@@ -53,11 +72,15 @@ export class ApplicationRepository {
       application.company,
       application.job_title,
       application.status,
+      application.apply_method ?? null,
       application.generated_resume ?? null,
       application.generated_cover_letter ?? null,
       application.form_data ? JSON.stringify(application.form_data) : null,
       application.error_message ?? null,
-      application.applied_at ?? null
+      application.notes ?? null,
+      application.base_resume_source ?? null,
+      application.applied_at ?? null,
+      application.created_at ?? null
     );
 
     const created = this.findById(Number(result.lastInsertRowid));
@@ -67,6 +90,27 @@ export class ApplicationRepository {
     return created;
   }
 
+  /**
+   * Record an attempt at a job. When retrying a failed application, update
+   * that row instead so a retry doesn't leave a second record behind.
+   */
+  createOrRetry(retryOfId: number | undefined, application: Omit<Application, 'id'>): Application {
+    const previous = retryOfId !== undefined ? this.findById(retryOfId) : null;
+    if (!previous || previous.status !== 'failed' || previous.url !== application.url) {
+      return this.create(application);
+    }
+
+    return this.update(previous.id!, {
+      status: application.status,
+      apply_method: application.apply_method,
+      generated_resume: application.generated_resume,
+      generated_cover_letter: application.generated_cover_letter,
+      form_data: application.form_data,
+      base_resume_source: application.base_resume_source,
+      error_message: '',
+    })!;
+  }
+
   findById(id: number): Application | null {
     const db = getDb();
     const row = db.query<ApplicationRow, [number]>('SELECT * FROM applications WHERE id = ?').get(id);
@@ -83,6 +127,15 @@ export class ApplicationRepository {
     return rows.map(rowToApplication);
   }
 
+  /** The most recently created application, if any. */
+  findLatest(): Application | null {
+    const db = getDb();
+    const row = db
+      .query<ApplicationRow, []>('SELECT * FROM applications ORDER BY created_at DESC, id DESC LIMIT 1')
+      .get();
+    return row ? rowToApplication(row) : null;
+  }
+
   existsByUrl(url: string): boolean {
     const db = getDb();
     const row = db
@@ -93,7 +146,7 @@ export class ApplicationRepository {
     return (row?.count ?? 0) > 0;
   }
 
-  findAll(filters?: { status?: ApplicationStatus; company?: string; profile_id?: number }): Application[] {
+  findAll(filters?: { status?: ApplicationStatus; company?: string; profile_id?: number; tag?: string }): Application[] {
     const db = getDb();
     let query = 'SELECT * FROM applications WHERE 1=1';
     const params: unknown[] = [];
@@ -110,6 +163,10 @@ export class ApplicationRepository {
       query += ' AND profile_id = ?';
       params.push(filters.profile_id);
     }
+    if (filters?.tag) {
+      query += ' AND id IN (SELECT application_id FROM application_tags WHERE tag = ?)';
+      params.push(normalizeTag(filters.tag));
+    }
 
     query += ' ORDER BY created_at DESC';
 
@@ -130,6 +187,10 @@ export class ApplicationRepository {
       fields.push('status = ?');
       values.push(updates.status);
     }
+    if (updates.apply_method !== undefined) {
+      fields.push('apply_method = ?');
+      values.push(updates.apply_method);
+    }
     if (updates.generated_resume !== undefined) {
       fields.push('generated_resume = ?');
       values.push(updates.generated_resume);
@@ -146,6 +207,14 @@ export class ApplicationRepository {
       fields.push('error_message = ?');
       values.push(updates.error_message);
     }
+    if (updates.notes !== undefined) {
+      fields.push('notes = ?');
+      values.push(updates.notes);
+    }
+    if (updates.base_resume_source !== undefined) {
+      fields.push('base_resume_source = ?');
+      values.push(updates.base_resume_source);
+    }
     if (updates.applied_at !== undefined) {
       fields.push('applied_at = ?');
       values.push(updates.applied_at);
@@ -159,6 +228,19 @@ export class ApplicationRepository {
     return this.findById(id);
   }
 
+  /**
+   * Add a timestamped line above any existing notes, so the newest note
+   * comes first and nothing is overwritten.
+   */
+  appendNotes(id: number, note: string, now = new Date()): Application | null {
+    const existing = this.findById(id);
+    if (!existing) return null;
+
+    const line = `[${now.toISOString().slice(0, 16).replace('T', ' ')}] ${note.trim()}`;
+    const notes = existing.notes ? `${line}\n${existing.notes}` : line;
+    return this.update(id, { notes });
+  }
+
   delete(id: number): boolean {
     const db = getDb();
     const result = db.run('DELETE FROM applications WHERE id = ?', [id]);
@@ -179,6 +261,86 @@ export class ApplicationRepository {
     const result = stmt.get(...(params as SQLQueryBindings[]));
     return result?.count ?? 0;
   }
+
+  /** Add tags to an application; returns its tags afterwards, or null if it doesn't exist. */
+  addTags(id: number, tags: string[]): string[] | null {
+    if (!this.findById(id)) return null;
+    const db = getDb();
+    const insert = db.prepare('INSERT OR IGNORE INTO application_tags (application_id, tag) VALUES (?, ?)');
+    db.transaction(() => {
+      for (const tag of tags.map(normalizeTag).filter(Boolean)) insert.run(id, tag);
+    })();
+    return this.getTags(id);
+  }
+
+  removeTags(id: number, tags: string[]): string[] | null {
+    if (!this.findById(id)) return null;
+    const db = getDb();
+    const remove = db.prepare('DELETE FROM application_tags WHERE application_id = ? AND tag = ?');
+    db.transaction(() => {
+      for (const tag of tags.map(normalizeTag)) remove.run(id, tag);
+    })();
+    return this.getTags(id);
+  }
+
+  /** Copy one application's tags onto another, e.g. before deleting a duplicate. */
+  copyTags(fromId: number, toId: number): string[] {
+    const db = getDb();
+    db.run(
+      'INSERT OR IGNORE INTO application_tags (application_id, tag) SELECT ?, tag FROM application_tags WHERE application_id = ?',
+      [toId, fromId]
+    );
+    return this.getTags(toId);
+  }
+
+  getTags(id: number): string[] {
+    const db = getDb();
+    return db
+      .query<{ tag: string }, [number]>('SELECT tag FROM application_tags WHERE application_id = ? ORDER BY tag')
+      .all(id)
+      .map((row) => row.tag);
+  }
+
+  /** Every tag in use with how many applications carry it. */
+  countTags(): Array<{ tag: string; count: number }> {
+    const db = getDb();
+    return db
+      .query<{ tag: string; count: number }, []>(
+        'SELECT tag, COUNT(*) AS count FROM application_tags GROUP BY tag ORDER BY count DESC, tag'
+      )
+      .all();
+  }
+
+  /**
+   * Applications submitted at or after `since`, across every run (used for the
+   * hourly/daily caps). Dry runs, previews, skips and failures don't count.
+   * datetime() compares ISO and SQLite-style timestamps alike.
+   */
+  countSubmittedSince(since: Date): number {
+    const db = getDb();
+    return db
+      .query<{ count: number }, [string]>(
+        "SELECT COUNT(*) AS count FROM applications WHERE status = 'submitted' AND datetime(applied_at) >= datetime(?)"
+      )
+      .get(since.toISOString())?.count ?? 0;
+  }
+
+  /** Application counts per apply method; records from before methods were tracked show as "unknown". */
+  countByMethod(): MethodStats[] {
+    const db = getDb();
+    return db
+      .query<MethodStats, []>(`
+        SELECT
+          COALESCE(apply_method, 'unknown') AS method,
+          COUNT(*) AS total,
+          SUM(CASE WHEN status = 'submitted' THEN 1 ELSE 0 END) AS submitted,
+          SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END) AS failed
+        FROM applications
+        GROUP BY method
+        ORDER BY total DESC, method
+      `)
+      .all();
+  }
 }
 
 export const applicationRepository = new ApplicationRepository();
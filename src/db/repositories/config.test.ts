@@ -0,0 +1,49 @@
+import { describe, expect, test, afterEach } from 'bun:test';
+import { mkdtempSync, readFileSync, rmSync, writeFileSync } from 'fs';
+import { tmpdir } from 'os';
+import { join } from 'path';
+import { configRepository, getConfigPath, setConfigPath } from './config';
+import { DEFAULT_CONFIG } from '../../types';
+
+let tempDir: string | undefined;
+
+function makeTempDir(): string {
+  tempDir = mkdtempSync(join(tmpdir(), 'autoply-config-'));
+  return tempDir;
+}
+
+afterEach(() => {
+  setConfigPath(null);
+  if (tempDir) rmSync(tempDir, { recursive: true, force: true });
+  tempDir = undefined;
+});
+
+describe('setConfigPath', () => {
+  test('loads config from the custom file', () => {
+    const path = join(makeTempDir(), 'ci.json');
+    writeFileSync(path, JSON.stringify({ ai: { ...DEFAULT_CONFIG.ai, provider: 'anthropic', model: 'custom' } }));
+
+    setConfigPath(path);
+
+    expect(getConfigPath()).toBe(path);
+    const config = configRepository.loadAppConfig();
+    expect(config.ai.provider).toBe('anthropic');
+    expect(config.ai.model).toBe('custom');
+    expect(config.browser).toEqual(DEFAULT_CONFIG.browser);
+  });
+
+  test('writes updates back to the custom file, creating its directory', () => {
+    const path = join(makeTempDir(), 'nested', 'autoply.json');
+    setConfigPath(path);
+
+    configRepository.setConfigValue('browser.headless', 'true');
+
+    const saved = JSON.parse(readFileSync(path, 'utf-8'));
+    expect(saved.browser.headless).toBe(true);
+  });
+
+  test('falls back to defaults when the custom file does not exist', () => {
+    setConfigPath(join(makeTempDir(), 'missing.json'));
+    expect(configRepository.loadAppConfig()).toEqual(DEFAULT_CONFIG);
+  });
+});
@@ -1,10 +1,22 @@
 import { getDb, getAutoplyDir } from '../index';
-import { join } from 'path';
-import { readFileSync, writeFileSync, existsSync } from 'fs';
+import { dirname, join, resolve } from 'path';
+import { readFileSync, writeFileSync, existsSync, mkdirSync } from 'fs';
 import type { AppConfig } from '../../types';
 import { DEFAULT_CONFIG } from '../../types';
 
-const CONFIG_FILE = join(getAutoplyDir(), 'config.json');
+let configPathOverride: string | null = null;
+
+/**
+ * Read and write config from another file (the --config flag). Pass null to
+ * go back to config.json in the data directory.
+ */
+export function setConfigPath(path: string | null): void {
+  configPathOverride = path ? resolve(path) : null;
+}
+
+export function getConfigPath(): string {
+  return configPathOverride ?? join(getAutoplyDir(), 'config.json');
+}
 
 export class ConfigRepository {
   // Database-based config (for key-value pairs)
@@ -40,19 +52,23 @@ export class ConfigRepository {
 
   // File-based config (for AppConfig object)
   loadAppConfig(): AppConfig {
-    if (existsSync(CONFIG_FILE)) {
+    const configPath = getConfigPath();
+    if (existsSync(configPath)) {
       try {
-        const content = readFileSync(CONFIG_FILE, 'utf-8');
-        return { ...DEFAULT_CONFIG, ...JSON.parse(content) };
+        const content = readFileSync(configPath, 'utf-8');
+        return { ...structuredClone(DEFAULT_CONFIG), ...JSON.parse(content) };
       } catch {
-        return DEFAULT_CONFIG;
+        return structuredClone(DEFAULT_CONFIG);
       }
     }
-    return DEFAULT_CONFIG;
+    // Copy so callers that mutate the result (setConfigValue) don't change the defaults
+    return structuredClone(DEFAULT_CONFIG);
   }
 
   saveAppConfig(config: AppConfig): void {
-    writeFileSync(CONFIG_FILE, JSON.stringify(config, null, 2));
+    const configPath = getConfigPath();
+    mkdirSync(dirname(configPath), { recursive: true });
+    writeFileSync(configPath, JSON.stringify(config, null, 2));
   }
 
   updateAppConfig(updates: Partial<AppConfig>): AppConfig {
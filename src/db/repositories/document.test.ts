@@ -0,0 +1,39 @@
+import { describe, expect, test } from 'bun:test';
+import { useTempDataDir } from '../test-helpers';
+import { documentRepository } from './document';
+
+const URL = 'https://jobs.lever.co/acme/1';
+
+useTempDataDir();
+
+function save(type: 'resume' | 'cover-letter', content: string, url = URL) {
+  return documentRepository.save({ url, company: 'Acme', job_title: 'Engineer', type, content });
+}
+
+describe('documentRepository', () => {
+  test('returns the latest document of the requested type', () => {
+    save('cover-letter', 'First draft');
+    save('resume', 'Resume');
+    save('cover-letter', 'Second draft');
+
+    expect(documentRepository.findLatest(URL, 'cover-letter')?.content).toBe('Second draft');
+    expect(documentRepository.findLatest(URL, 'resume')?.content).toBe('Resume');
+  });
+
+  test('matches the job URL after normalization', () => {
+    save('cover-letter', 'Letter', `${URL}?utm_source=linkedin`);
+
+    expect(documentRepository.findLatest(URL, 'cover-letter')?.content).toBe('Letter');
+  });
+
+  test('records word and character counts', () => {
+    const saved = save('cover-letter', 'Dear Acme, hello.');
+
+    expect(saved.word_count).toBe(3);
+    expect(saved.char_count).toBe(17);
+  });
+
+  test('is null when nothing was generated for the job', () => {
+    expect(documentRepository.findLatest(URL, 'cover-letter')).toBeNull();
+  });
+});
@@ -0,0 +1,71 @@
+import { getDb } from '../index';
+import { normalizeUrl } from '../../utils/url-parser';
+import { countText } from '../../utils/text-length';
+
+export type DocumentType = 'resume' | 'cover-letter';
+
+export interface SavedDocument {
+  id: number;
+  url: string;
+  company: string;
+  job_title: string;
+  type: DocumentType;
+  content: string;
+  /** Null for documents saved before counts were recorded */
+  word_count: number | null;
+  char_count: number | null;
+  created_at: string;
+}
+
+export class DocumentRepository {
+  save(document: Omit<SavedDocument, 'id' | 'created_at' | 'word_count' | 'char_count'>): SavedDocument {
+    const db = getDb();
+    const { words, chars } = countText(document.content);
+    const result = db.run(
+      'INSERT INTO generated_documents (url, company, job_title, type, content, word_count, char_count) VALUES (?, ?, ?, ?, ?, ?, ?)',
+      [normalizeUrl(document.url), document.company, document.job_title, document.type, document.content, words, chars]
+    );
+    return db
+      .query<SavedDocument, [number]>('SELECT * FROM generated_documents WHERE id = ?')
+      .get(Number(result.lastInsertRowid))!;
+  }
+
+  /** Put back a document from an archive, keeping its date and counts. */
+  restore(document: Omit<SavedDocument, 'id'>): SavedDocument {
+    const db = getDb();
+    const result = db.run(
+      `INSERT INTO generated_documents (url, company, job_title, type, content, word_count, char_count, created_at)
+       VALUES (?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP))`,
+      [
+        document.url,
+        document.company,
+        document.job_title,
+        document.type,
+        document.content,
+        document.word_count ?? null,
+        document.char_count ?? null,
+        document.created_at ?? null,
+      ]
+    );
+    return db
+      .query<SavedDocument, [number]>('SELECT * FROM generated_documents WHERE id = ?')
+      .get(Number(result.lastInsertRowid))!;
+  }
+
+  findAll(): SavedDocument[] {
+    const db = getDb();
+    return db.query<SavedDocument, []>('SELECT * FROM generated_documents ORDER BY id').all();
+  }
+
+  /** The most recently generated document of a type for a job URL. */
+  findLatest(url: string, type: DocumentType): SavedDocument | null {
+    const db = getDb();
+    return db
+      .query<SavedDocument, [string, DocumentType]>(
+        'SELECT * FROM generated_documents WHERE url = ? AND type = ? ORDER BY created_at DESC, id DESC LIMIT 1'
+      )
+      .get(normalizeUrl(url), type) ?? null;
+  }
+}
+
+export const documentRepository = new DocumentRepository();
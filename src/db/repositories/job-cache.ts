@@ -0,0 +1,53 @@
+import { getDb } from '../index';
+import type { JobData, Platform } from '../../types';
+import { normalizeUrl } from '../../utils/url-parser';
+
+export interface JobCacheRow {
+  url: string;
+  platform: string;
+  job_data: string;
+  cached_at: number;
+}
+
+export interface CachedJob {
+  jobData: JobData;
+  platform: Platform;
+  cachedAt: number;
+}
+
+export class JobCacheRepository {
+  /** Returns the cached scrape for a URL if it is younger than maxAgeMs. */
+  get(url: string, maxAgeMs: number, now = Date.now()): CachedJob | null {
+    const db = getDb();
+    const row = db
+      .query<JobCacheRow, [string]>('SELECT * FROM job_cache WHERE url = ?')
+      .get(normalizeUrl(url));
+    if (!row || now - row.cached_at > maxAgeMs) return null;
+
+    try {
+      return {
+        jobData: JSON.parse(row.job_data) as JobData,
+        platform: row.platform as Platform,
+        cachedAt: row.cached_at,
+      };
+    } catch {
+      return null;
+    }
+  }
+
+  save(url: string, platform: Platform, jobData: JobData, now = Date.now()): void {
+    const db = getDb();
+    db.run(
+      `INSERT INTO job_cache (url, platform, job_data, cached_at) VALUES (?, ?, ?, ?)
+       ON CONFLICT(url) DO UPDATE SET platform = excluded.platform, job_data = excluded.job_data, cached_at = excluded.cached_at`,
+      [normalizeUrl(url), platform, JSON.stringify(jobData), now]
+    );
+  }
+
+  clear(): number {
+    const db = getDb();
+    return db.run('DELETE FROM job_cache').changes;
+  }
+}
+
+export const jobCacheRepository = new JobCacheRepository();
@@ -0,0 +1,134 @@
+import { describe, expect, test } from 'bun:test';
+import { getDb } from '../index';
+import { createTestProfile, useTempDataDir } from '../test-helpers';
+import { parsePreferences, profileRepository } from './profile';
+import { describePreferences, updatePreferences } from '../../core/preferences';
+
+useTempDataDir();
+
+describe('profile portfolio_url', () => {
+  test('round-trips through create and findById', () => {
+    const created = createTestProfile({ portfolio_url: 'https://ada.dev' });
+    expect(profileRepository.findById(created.id!)?.portfolio_url).toBe('https://ada.dev');
+  });
+
+  test('is undefined when not set', () => {
+    const created = createTestProfile();
+    expect(created.portfolio_url).toBeUndefined();
+  });
+
+  test('can be updated without touching other fields', () => {
+    const created = createTestProfile();
+    const updated = profileRepository.update(created.id!, { portfolio_url: 'https://ada.dev/work' });
+
+    expect(updated?.portfolio_url).toBe('https://ada.dev/work');
+    expect(updated?.email).toBe('ada@example.com');
+  });
+});
+
+describe('experience skills', () => {
+  test('round-trip with each role and survive an update', () => {
+    const created = createTestProfile();
+    profileRepository.update(created.id!, {
+      experience: [
+        { company: 'Acme', title: 'Engineer', start_date: '2022', highlights: [], skills: ['Go', 'PostgreSQL'] },
+        { company: 'Initech', title: 'Intern', start_date: '2020', end_date: '2021', highlights: [] },
+      ],
+    });
+
+    const [tagged, untagged] = profileRepository.findById(created.id!)!.experience;
+    expect(tagged.skills).toEqual(['Go', 'PostgreSQL']);
+    expect(untagged.skills).toBeUndefined();
+  });
+});
+
+describe('parsePreferences', () => {
+  const defaults = { remote_only: false, preferred_locations: [], excluded_companies: [], job_types: ['full-time'] };
+
+  test('fills defaults around valid preferences', () => {
+    expect(parsePreferences('{"remote_only": true, "min_salary": 120000}')).toEqual({
+      preferences: { ...defaults, remote_only: true, min_salary: 120000 },
+      invalid: [],
+    });
+  });
+
+  test('treats empty values as defaults', () => {
+    expect(parsePreferences('').preferences).toEqual(defaults);
+    expect(parsePreferences(null).preferences).toEqual(defaults);
+    expect(parsePreferences('null').preferences).toEqual(defaults);
+  });
+
+  test('rejects corrupt JSON', () => {
+    expect(() => parsePreferences('{"remote_only": tru')).toThrow('not valid JSON');
+    expect(() => parsePreferences('["remote"]')).toThrow('JSON object');
+  });
+
+  test('drops only the invalid fields and keeps the rest', () => {
+    expect(
+      parsePreferences('{"remote_only": true, "min_salary": -5, "job_types": "full-time", "excluded_companies": ["Initech"]}')
+    ).toEqual({
+      preferences: { ...defaults, remote_only: true, excluded_companies: ['Initech'] },
+      invalid: ['min_salary', 'job_types'],
+    });
+  });
+
+  test('treats a null salary (a NaN saved as JSON) as unset', () => {
+    expect(parsePreferences('{"min_salary": null, "preferred_locations": ["Berlin"]}')).toEqual({
+      preferences: { ...defaults, preferred_locations: ['Berlin'] },
+      invalid: [],
+    });
+  });
+
+  test('a profile with one bad field keeps its other preferences through an update', () => {
+    const created = createTestProfile();
+    getDb().run('UPDATE profiles SET preferences = ? WHERE id = ?', [
+      '{"remote_only": true, "min_salary": "lots", "excluded_companies": ["Initech"]}',
+      created.id!,
+    ]);
+
+    const loaded = profileRepository.findById(created.id!)!;
+    profileRepository.update(created.id!, { preferences: loaded.preferences });
+
+    expect(profileRepository.findById(created.id!)?.preferences).toEqual({
+      ...defaults,
+      remote_only: true,
+      excluded_companies: ['Initech'],
+    });
+  });
+
+  test('a profile with corrupt preferences loads with defaults', () => {
+    const created = createTestProfile();
+    getDb().run('UPDATE profiles SET preferences = ? WHERE id = ?', ['{oops', created.id!]);
+
+    const loaded = profileRepository.findById(created.id!);
+    expect(loaded?.preferences).toEqual(defaults);
+    expect(loaded?.email).toBe('ada@example.com');
+  });
+});
+
+describe('preferences set/show round-trip', () => {
+  test('changes persist through the JSON column and keep the blocklist', () => {
+    const created = profileRepository.update(createTestProfile().id!, {
+      preferences: { remote_only: false, job_types: [], preferred_locations: [], excluded_companies: ['Initech'] },
+    })!;
+
+    profileRepository.update(created.id!, {
+      preferences: updatePreferences(created.preferences, {
+        remoteOnly: true,
+        minSalary: 120000,
+        locations: ['Berlin', 'Remote'],
+      }),
+    });
+
+    const loaded = profileRepository.findById(created.id!)!;
+    expect(loaded.preferences).toEqual({
+      remote_only: true,
+      min_salary: 120000,
+      preferred_locations: ['Berlin', 'Remote'],
+      excluded_companies: ['Initech'],
+      job_types: [],
+    });
+    expect(describePreferences(loaded.preferences)).toContainEqual(['Locations', 'Berlin, Remote']);
+  });
+});
+
@@ -1,5 +1,6 @@
 import { getDb } from '../index';
-import type { Profile, Preferences, Experience, Education } from '../../types';
+import { PreferencesSchema, type Profile, type Preferences, type Experience, type Education } from '../../types';
+import { logger } from '../../utils/logger';
 
 export interface ProfileRow {
   id: number;
@@ -28,6 +29,75 @@ function safeJsonParse<T>(value: string, fallback: T): T {
   }
 }
 
+export interface ParsedPreferences {
+  preferences: Preferences;
+  /** Fields that failed validation and were replaced by their defaults */
+  invalid: string[];
+}
+
+/**
+ * Parse and validate the stored preferences JSON. Empty means defaults. An
+ * invalid field (e.g. a negative salary) falls back to its default on its
+ * own, so one bad value doesn't wipe the rest; null counts as unset.
+ * Malformed JSON throws.
+ */
+export function parsePreferences(value: string | null | undefined): ParsedPreferences {
+  const defaults = PreferencesSchema.parse({});
+  if (!value?.trim()) return { preferences: defaults, invalid: [] };
+
+  let raw: unknown;
+  try {
+    raw = JSON.parse(value);
+  } catch {
+    throw new Error('Preferences are not valid JSON');
+  }
+  if (raw === null) return { preferences: defaults, invalid: [] };
+  if (typeof raw !== 'object' || Array.isArray(raw)) {
+    throw new Error('Preferences must be a JSON object');
+  }
+
+  const fields = PreferencesSchema.shape;
+  const preferences: Record<string, unknown> = { ...defaults };
+  const invalid: string[] = [];
+  for (const [key, fieldValue] of Object.entries(raw as Record<string, unknown>)) {
+    if (!(key in fields) || fieldValue === null) continue;
+    const result = fields[key as keyof typeof fields].safeParse(fieldValue);
+    if (result.success) {
+      preferences[key] = result.data;
+    } else {
+      invalid.push(key);
+    }
+  }
+  return { preferences: preferences as Preferences, invalid };
+}
+
+const warnedProfiles = new Set<number>();
+
+/**
+ * Preferences for a stored profile. Invalid fields fall back to their
+ * defaults, and unreadable JSON to all defaults, with one warning per profile
+ * per run.
+ */
+function loadPreferences(row: ProfileRow): Preferences {
+  let parsed: ParsedPreferences;
+  let problem: string | undefined;
+  try {
+    parsed = parsePreferences(row.preferences);
+    if (parsed.invalid.length > 0) problem = `Invalid preferences (${parsed.invalid.join(', ')})`;
+  } catch (error) {
+    parsed = { preferences: PreferencesSchema.parse({}), invalid: [] };
+    problem = error instanceof Error ? error.message : 'Unreadable preferences';
+  }
+
+  if (problem && !warnedProfiles.has(row.id)) {
+    warnedProfiles.add(row.id);
+    logger.warning(
+      `${problem} for profile #${row.id}; using defaults for those. Run "autoply profile preferences set" to fix them.`
+    );
+  }
+  return parsed.preferences;
+}
+
 function rowToProfile(row: ProfileRow): Profile {
   return {
     id: row.id,
@@ -40,7 +110,7 @@ function rowToProfile(row: ProfileRow): Profile {
     portfolio_url: row.portfolio_url ?? undefined,
     base_resume: row.base_resume ?? undefined,
     base_cover_letter: row.base_cover_letter ?? undefined,
-    preferences: safeJsonParse<Preferences>(row.preferences, {} as Preferences),
+    preferences: loadPreferences(row),
     skills: safeJsonParse<string[]>(row.skills, []),
     experience: safeJsonParse<Experience[]>(row.experience, []),
     education: safeJsonParse<Education[]>(row.education, []),
@@ -50,13 +120,15 @@ function rowToProfile(row: ProfileRow): Profile {
 }
 
 export class ProfileRepository {
-  create(profile: Omit<Profile, 'id' | 'created_at' | 'updated_at'>): Profile {
+  /** Timestamps default to now; pass them to keep the originals (e.g. when importing) */
+  create(profile: Omit<Profile, 'id'>): Profile {
     const db = getDb();
     const stmt = db.prepare(`
       INSERT INTO profiles (
         name, email, phone, location, linkedin_url, github_url, portfolio_url,
-        base_resume, base_cover_letter, preferences, skills, experience, education
-      ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        base_resume, base_cover_letter, preferences, skills, experience, education,
+        created_at, updated_at
+      ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), COALESCE(?, CURRENT_TIMESTAMP))
     `);
 
     const result = stmt.run(
@@ -72,7 +144,9 @@ export class ProfileRepository {
       JSON.stringify(profile.preferences ?? {}),
       JSON.stringify(profile.skills ?? []),
       JSON.stringify(profile.experience ?? []),
-      JSON.stringify(profile.education ?? [])
+      JSON.stringify(profile.education ?? []),
+      profile.created_at ?? null,
+      profile.updated_at ?? null
     );
 
     const created = this.findById(Number(result.lastInsertRowid));
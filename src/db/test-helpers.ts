@@ -0,0 +1,50 @@
+import { afterEach, beforeEach } from 'bun:test';
+import { mkdtempSync, rmSync } from 'fs';
+import { tmpdir } from 'os';
+import { join } from 'path';
+import { setAutoplyDir } from './index';
+import { profileRepository } from './repositories/profile';
+import type { Profile } from '../types';
+
+/**
+ * Give every test in the file its own empty data directory, so each starts
+ * with a fresh database and nothing touches ~/.autoply. The returned function
+ * switches to another fresh directory mid-test (e.g. to restore an export
+ * somewhere clean). All of them are removed after each test.
+ */
+export function useTempDataDir(): () => string {
+  const dirs: string[] = [];
+
+  const fresh = (): string => {
+    const dir = mkdtempSync(join(tmpdir(), 'autoply-test-'));
+    dirs.push(dir);
+    setAutoplyDir(dir);
+    return dir;
+  };
+
+  beforeEach(() => {
+    fresh();
+  });
+
+  afterEach(() => {
+    setAutoplyDir(null);
+    for (const dir of dirs.splice(0)) rmSync(dir, { recursive: true, force: true });
+  });
+
+  return fresh;
+}
+
+/**
+ * Create a minimal profile in the current database.
+ */
+export function createTestProfile(overrides: Partial<Omit<Profile, 'id'>> = {}): Profile {
+  return profileRepository.create({
+    name: 'Ada Lovelace',
+    email: 'ada@example.com',
+    preferences: { remote_only: false, job_types: [], preferred_locations: [], excluded_companies: [] },
+    skills: [],
+    experience: [],
+    education: [],
+    ...overrides,
+  });
+}
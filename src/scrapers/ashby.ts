@@ -33,6 +33,10 @@ export class AshbyScraper extends BaseScraper {
       // Fill form
       await this.fillAshbyForm(options, errors);
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickAshbySubmit();
       if (!submitted) {
@@ -1,6 +1,8 @@
 import { BaseScraper, type SubmissionOptions, type SubmissionResult } from './base';
 import type { JobData, CustomQuestion, Platform, Profile } from '../types';
 import { FormFiller } from '../core/form-filler';
+import { parseJsonObject, asString, asStringArray } from '../ai/response';
+import { splitName } from '../utils/name';
 
 export class BambooHRScraper extends BaseScraper {
   platform: Platform = 'bamboohr';
@@ -47,15 +49,14 @@ ${pageText.slice(0, 6000)}`,
         'You extract structured job data from web pages. Return valid JSON only, no markdown fences.'
       );
 
-      const cleaned = response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
-      const parsed = JSON.parse(cleaned);
+      const parsed = parseJsonObject(response);
 
-      title = parsed.title || title;
-      company = parsed.company || company;
-      description = parsed.description || description;
-      requirements = parsed.requirements || [];
-      qualifications = parsed.qualifications || [];
-      location = parsed.location;
+      title = asString(parsed.title) ?? title;
+      company = asString(parsed.company) ?? company;
+      description = asString(parsed.description) ?? description;
+      requirements = asStringArray(parsed.requirements);
+      qualifications = asStringArray(parsed.qualifications);
+      location = asString(parsed.location);
     } catch {
       // Fallback: try to extract title from page
       const h2Text = await this.extractText('h2');
@@ -90,7 +91,7 @@ ${pageText.slice(0, 6000)}`,
 
       // Navigate to job posting
       await this.humanDelay();
-      await this.page.goto(url, { waitUntil: 'domcontentloaded', timeout: 60000 });
+      await this.page.goto(url, { waitUntil: 'domcontentloaded', timeout: this.pageTimeout * 2 });
       await this.waitForContent();
       await this.humanDelay(true);
       await this.humanScroll();
@@ -173,6 +174,10 @@ ${pageText.slice(0, 6000)}`,
         await this.takeScreenshot(preScreenshot);
       }
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickSubmitBtn();
       if (!submitted) {
@@ -262,9 +267,7 @@ ${pageText.slice(0, 6000)}`,
     if (!this.page) return;
     const { profile } = options;
 
-    const nameParts = profile.name.split(' ');
-    const firstName = nameParts[0];
-    const lastName = nameParts.slice(1).join(' ');
+    const { first: firstName, last: lastName } = splitName(profile.name);
 
     // BambooHR uses various input patterns
     const fieldMappings: Array<{ selectors: string[]; value: string }> = [
@@ -1,5 +1,6 @@
-import { describe, expect, test } from 'bun:test';
-import { BaseScraper } from './base';
+import { describe, expect, test, afterEach } from 'bun:test';
+import { BaseScraper, chooseCoverLetterMethod, closeAllBrowsers, PREVIEW_MESSAGE, resolvePageTimeout, scrollUntilStable, setPageTimeout, type SubmissionOptions } from './base';
+import type { Page } from 'playwright';
 import type { JobData, Platform } from '../types';
 
 // Create a concrete implementation for testing the base class methods
@@ -384,3 +385,211 @@ What we offer:
     });
   });
 });
+
+describe('scrollUntilStable', () => {
+  /** Fake page: each scroll reveals the next count; past the end the count stays put. */
+  function counter(counts: number[]) {
+    let calls = 0;
+    return {
+      scroll: async () => counts[Math.min(calls++, counts.length - 1)],
+      calls: () => calls,
+    };
+  }
+
+  test('stops on the first scroll that loads nothing new', async () => {
+    const page = counter([10, 20, 20, 30]);
+    expect(await scrollUntilStable(page.scroll, 10)).toBe(3);
+    expect(page.calls()).toBe(3);
+  });
+
+  test('keeps going while content grows, up to the limit', async () => {
+    const page = counter([1, 2, 3, 4, 5, 6]);
+    expect(await scrollUntilStable(page.scroll, 4)).toBe(4);
+  });
+
+  test('stops after the second scroll on a page that never grows', async () => {
+    const page = counter([0]);
+    expect(await scrollUntilStable(page.scroll, 4)).toBe(2);
+  });
+
+  test('does nothing with a limit of zero', async () => {
+    const page = counter([5]);
+    expect(await scrollUntilStable(page.scroll, 0)).toBe(0);
+  });
+});
+
+describe('chooseCoverLetterMethod', () => {
+  const base = { hasText: true, hasFile: true, textareaFound: false, uploadFound: false };
+
+  test('prefers pasting into a textarea when the form has one', () => {
+    expect(chooseCoverLetterMethod({ ...base, textareaFound: true, uploadFound: true })).toBe('textarea');
+  });
+
+  test('falls back to upload when there is no textarea', () => {
+    expect(chooseCoverLetterMethod({ ...base, uploadFound: true })).toBe('upload');
+  });
+
+  test('uploads a rendered PDF when only text is available', () => {
+    expect(chooseCoverLetterMethod({ ...base, hasFile: false, uploadFound: true })).toBe('upload');
+  });
+
+  test('uploads the prepared file when there is no text to paste', () => {
+    expect(
+      chooseCoverLetterMethod({ ...base, hasText: false, textareaFound: true, uploadFound: true })
+    ).toBe('upload');
+  });
+
+  test('does nothing when the form has neither field', () => {
+    expect(chooseCoverLetterMethod(base)).toBe('none');
+  });
+
+  test('does nothing when there is no cover letter', () => {
+    expect(
+      chooseCoverLetterMethod({ hasText: false, hasFile: false, textareaFound: true, uploadFound: true })
+    ).toBe('none');
+  });
+});
+
+// Drives the base submit flow against a stub page so only the submit step is observed
+class SubmitHarness extends TestScraper {
+  submitClicks = 0;
+
+  override async initialize(): Promise<void> {
+    this.page = {
+      goto: async () => null,
+      screenshot: async () => Buffer.from(''),
+      waitForEvent: async () => undefined,
+    } as unknown as Page;
+  }
+
+  override async cleanup(): Promise<void> {
+    this.page = null;
+  }
+
+  protected override async humanDelay(): Promise<void> {}
+  protected override async humanScroll(): Promise<void> {}
+  protected override async navigateToApplicationForm(): Promise<void> {}
+  protected override async waitForApplicationForm(): Promise<void> {}
+
+  protected override async validateBeforeSubmit(): Promise<{ valid: boolean; errors: string[] }> {
+    return { valid: true, errors: [] };
+  }
+
+  protected override async clickSubmitButton(): Promise<boolean> {
+    this.submitClicks++;
+    return true;
+  }
+
+  protected override async waitForSubmissionConfirmation(): Promise<{ success: boolean; message: string }> {
+    return { success: true, message: 'Submitted' };
+  }
+}
+
+describe('submitApplication preview', () => {
+  const jobData: JobData = {
+    url: 'https://example.com/job',
+    platform: 'greenhouse',
+    title: 'Engineer',
+    company: 'Acme',
+    description: '',
+    requirements: [],
+    qualifications: [],
+    form_fields: [],
+    custom_questions: [],
+  };
+
+  const options: SubmissionOptions = {
+    profile: { name: 'Ada Lovelace', email: 'ada@example.com', skills: [], experience: [], education: [] },
+    jobData,
+    documents: { resume: '', coverLetter: '' },
+  };
+
+  test('skips the submit click in preview mode', async () => {
+    const scraper = new SubmitHarness();
+    const result = await scraper.submitApplication(jobData.url, { ...options, preview: true });
+
+    expect(scraper.submitClicks).toBe(0);
+    expect(result.success).toBe(true);
+    expect(result.message).toBe(PREVIEW_MESSAGE);
+  });
+
+  test('clicks submit otherwise', async () => {
+    const scraper = new SubmitHarness();
+    const result = await scraper.submitApplication(jobData.url, options);
+
+    expect(scraper.submitClicks).toBe(1);
+    expect(result.message).toBe('Submitted');
+  });
+});
+
+describe('resolvePageTimeout', () => {
+  afterEach(() => {
+    setPageTimeout(null);
+  });
+
+  test('uses the config timeout by default', () => {
+    expect(resolvePageTimeout(30000)).toBe(30000);
+  });
+
+  test('--timeout overrides the config value', () => {
+    setPageTimeout(5000);
+    expect(resolvePageTimeout(30000)).toBe(5000);
+  });
+
+  test('platform multipliers stay relative to the override', () => {
+    setPageTimeout(5000);
+    expect(resolvePageTimeout(30000, 2)).toBe(10000);
+  });
+});
+
+describe('closeAllBrowsers', () => {
+  class TrackedScraper extends TestScraper {
+    cleanups = 0;
+
+    track(): void {
+      this.trackBrowser();
+    }
+
+    override async cleanup(): Promise<void> {
+      this.cleanups++;
+      await super.cleanup();
+    }
+  }
+
+  test('cleans up every scraper that is still open', async () => {
+    const first = new TrackedScraper();
+    const second = new TrackedScraper();
+    first.track();
+    second.track();
+
+    await closeAllBrowsers();
+
+    expect(first.cleanups).toBe(1);
+    expect(second.cleanups).toBe(1);
+  });
+
+  test('skips scrapers that already cleaned up', async () => {
+    const scraper = new TrackedScraper();
+    scraper.track();
+    await scraper.cleanup();
+
+    await closeAllBrowsers();
+
+    expect(scraper.cleanups).toBe(1);
+  });
+
+  test('does not wait forever on a hung browser', async () => {
+    class HungScraper extends TrackedScraper {
+      override cleanup(): Promise<void> {
+        return new Promise(() => {});
+      }
+    }
+    const hung = new HungScraper();
+    hung.track();
+
+    const started = Date.now();
+    await closeAllBrowsers(50);
+
+    expect(Date.now() - started).toBeLessThan(1000);
+  });
+});
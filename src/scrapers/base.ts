@@ -1,9 +1,15 @@
 import type { Browser, Page, BrowserContext } from 'playwright';
-import { existsSync } from 'fs';
+import { existsSync, unlinkSync } from 'fs';
+import { tmpdir } from 'os';
+import { join } from 'path';
 import type { JobData, FormField, CustomQuestion, Platform, Profile, GeneratedDocuments, AIProvider } from '../types';
 import { configRepository } from '../db/repositories/config';
 import { FormFiller, type FormFillerOptions, type FillResult } from '../core/form-filler';
 import { extractJobDataWithAI, mergeJobData } from '../ai/job-extractor';
+import { generateCoverLetterPdf } from '../core/document';
+import { applyStealth, browserContextOptions, browserLaunchArgs } from './stealth';
+import { Semaphore } from '../utils/semaphore';
+import { logger } from '../utils/logger';
 
 export interface SubmissionResult {
   success: boolean;
@@ -19,6 +25,46 @@ export interface SubmissionOptions {
   resumePath?: string;
   coverLetterPath?: string;
   answeredQuestions?: CustomQuestion[];
+  /** Fill everything but stop before clicking submit */
+  preview?: boolean;
+}
+
+export const PREVIEW_MESSAGE = 'Filled, not submitted (preview)';
+
+let pageTimeoutOverride: number | null = null;
+
+/**
+ * Override browser.timeout for this run (the --timeout flag). Pass null to
+ * go back to the config value.
+ */
+export function setPageTimeout(ms: number | null): void {
+  pageTimeoutOverride = ms;
+}
+
+/**
+ * Effective per-page timeout in ms. Slow platforms pass a multiplier so
+ * they stay proportionally longer than the base timeout.
+ */
+export function resolvePageTimeout(configTimeout: number, multiplier = 1): number {
+  return Math.round((pageTimeoutOverride ?? configTimeout) * multiplier);
+}
+
+export type CoverLetterMethod = 'textarea' | 'upload' | 'none';
+
+/**
+ * Decide how to attach a cover letter. Pasting text is preferred since it
+ * shows inline for reviewers; forms that only take a file get an upload,
+ * rendered from the text if no PDF was prepared.
+ */
+export function chooseCoverLetterMethod(state: {
+  hasText: boolean;
+  hasFile: boolean;
+  textareaFound: boolean;
+  uploadFound: boolean;
+}): CoverLetterMethod {
+  if (state.textareaFound && state.hasText) return 'textarea';
+  if (state.uploadFound && (state.hasFile || state.hasText)) return 'upload';
+  return 'none';
 }
 
 // Random delay to mimic human behavior
@@ -27,67 +73,94 @@ function randomDelay(min: number, max: number): Promise<void> {
   return new Promise((resolve) => setTimeout(resolve, delay));
 }
 
+export const DEFAULT_MAX_SCROLLS = 4;
+
+/**
+ * Call `scroll` (which scrolls once and returns how much content is loaded)
+ * until the count stops growing or `maxScrolls` is reached. Returns the
+ * number of scrolls made.
+ */
+export async function scrollUntilStable(
+  scroll: () => Promise<number>,
+  maxScrolls: number = DEFAULT_MAX_SCROLLS
+): Promise<number> {
+  let previous = -1;
+  let scrolls = 0;
+  while (scrolls < maxScrolls) {
+    const loaded = await scroll();
+    scrolls++;
+    if (loaded <= previous) break;
+    previous = loaded;
+  }
+  return scrolls;
+}
+
+const activeScrapers = new Set<BaseScraper>();
+
+export const DEFAULT_MAX_BROWSERS = 2;
+let browserSlots: Semaphore | null = null;
+
+/** The app-wide browser limit, sized from browser.maxBrowsers the first time it's needed. */
+function getBrowserSlots(maxBrowsers: number | undefined): Semaphore {
+  if (!browserSlots) {
+    const limit = maxBrowsers !== undefined && Number.isInteger(maxBrowsers) && maxBrowsers > 0
+      ? maxBrowsers
+      : DEFAULT_MAX_BROWSERS;
+    browserSlots = new Semaphore(limit);
+  }
+  return browserSlots;
+}
+
+/**
+ * Close every browser that hasn't been cleaned up yet. Called on Ctrl+C so
+ * an interrupted scrape doesn't leave Chromium running; gives up after timeoutMs.
+ */
+export async function closeAllBrowsers(timeoutMs = 5000): Promise<void> {
+  if (activeScrapers.size === 0) return;
+  const closing = Promise.all([...activeScrapers].map((scraper) => scraper.cleanup().catch(() => {})));
+  let timer: ReturnType<typeof setTimeout> | undefined;
+  const timeout = new Promise<void>((resolve) => {
+    timer = setTimeout(resolve, timeoutMs);
+  });
+  await Promise.race([closing, timeout]);
+  clearTimeout(timer);
+}
+
 export abstract class BaseScraper {
   abstract platform: Platform;
   protected browser: Browser | null = null;
   protected context: BrowserContext | null = null;
   protected page: Page | null = null;
+  protected pageTimeout = 30000;
+  protected maxScrolls = DEFAULT_MAX_SCROLLS;
+  private releaseBrowserSlot: (() => void) | null = null;
 
   async initialize(): Promise<void> {
     const config = configRepository.loadAppConfig();
     const { chromium } = await import('playwright');
+    // Wait for a free slot so parallel scrapes can't open more than browser.maxBrowsers
+    this.releaseBrowserSlot ??= await getBrowserSlots(config.browser.maxBrowsers).acquire();
+    this.trackBrowser();
     this.browser = await chromium.launch({
       headless: config.browser.headless,
-      args: [
-        '--disable-blink-features=AutomationControlled',
-        '--disable-features=IsolateOrigins,site-per-process',
-      ],
+      // The CLI's own signal handler closes browsers via closeAllBrowsers()
+      handleSIGINT: false,
+      handleSIGTERM: false,
+      args: browserLaunchArgs(config.browser.stealth !== false),
     });
     this.context = await this.browser.newContext({
-      userAgent:
-        'Mozilla/5.0 (Macintosh; Apple Silicon Mac OS X 14_0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36',
+      ...browserContextOptions(config.browser),
       storageState: config.browser.storageState && existsSync(config.browser.storageState)
         ? config.browser.storageState
         : undefined,
-      viewport: { width: 1920, height: 1080 },
-      locale: Intl.DateTimeFormat().resolvedOptions().locale || 'en-US',
-      timezoneId: Intl.DateTimeFormat().resolvedOptions().timeZone || 'UTC',
     });
 
-    // Mask automation indicators
-    await this.context.addInitScript(() => {
-      // Remove webdriver flag
-      Object.defineProperty(navigator, 'webdriver', { get: () => undefined });
-
-      // Mock plugins (real browsers have these)
-      Object.defineProperty(navigator, 'plugins', {
-        get: () => [
-          { name: 'Chrome PDF Plugin', filename: 'internal-pdf-viewer' },
-          { name: 'Chrome PDF Viewer', filename: 'mhjfbmdgcfjbbpaeojofohoefgiehjai' },
-          { name: 'Native Client', filename: 'internal-nacl-plugin' },
-        ],
-      });
-
-      // Mock languages
-      Object.defineProperty(navigator, 'languages', {
-        get: () => navigator.language ? [navigator.language, 'en'] : ['en'],
-      });
-
-      // Hide automation-related Chrome properties
-      const originalQuery = window.navigator.permissions.query;
-      window.navigator.permissions.query = (parameters: PermissionDescriptor) => {
-        if (parameters.name === 'notifications') {
-          return Promise.resolve({ state: 'prompt', onchange: null } as PermissionStatus);
-        }
-        return originalQuery(parameters);
-      };
-
-      // Mask Chrome property
-      (window as unknown as { chrome: unknown }).chrome = { runtime: {} };
-    });
+    await applyStealth(this.context, config.browser.stealth !== false);
 
     this.page = await this.context.newPage();
-    this.page.setDefaultTimeout(config.browser.timeout);
+    this.pageTimeout = resolvePageTimeout(config.browser.timeout);
+    this.maxScrolls = config.browser.maxScrolls ?? DEFAULT_MAX_SCROLLS;
+    this.page.setDefaultTimeout(this.pageTimeout);
   }
 
   // Add human-like delay between actions
@@ -99,28 +172,40 @@ export abstract class BaseScraper {
     }
   }
 
-  // Simulate human-like scrolling
+  // Simulate human-like scrolling, stopping once the bottom is reached and nothing more loads
   protected async humanScroll(): Promise<void> {
-    if (!this.page) return;
+    const page = this.page;
+    if (!page) return;
 
-    const scrolls = Math.floor(Math.random() * 3) + 2; // 2-4 scrolls
-    for (let i = 0; i < scrolls; i++) {
+    await scrollUntilStable(async () => {
       const scrollAmount = Math.floor(Math.random() * 300) + 100;
-      await this.page.mouse.wheel(0, scrollAmount);
+      await page.mouse.wheel(0, scrollAmount);
       await randomDelay(500, 1500);
-    }
+      // Lowest point seen so far: grows while there's more to scroll or lazy content arrives
+      return page.evaluate(() => Math.min(window.scrollY + window.innerHeight, document.body.scrollHeight));
+    }, this.maxScrolls);
+  }
+
+  protected trackBrowser(): void {
+    activeScrapers.add(this);
   }
 
   async cleanup(): Promise<void> {
-    if (this.context) {
-      await this.context.close();
-      this.context = null;
-    }
-    if (this.browser) {
-      await this.browser.close();
-      this.browser = null;
+    activeScrapers.delete(this);
+    try {
+      if (this.context) {
+        await this.context.close();
+        this.context = null;
+      }
+      if (this.browser) {
+        await this.browser.close();
+        this.browser = null;
+      }
+      this.page = null;
+    } finally {
+      this.releaseBrowserSlot?.();
+      this.releaseBrowserSlot = null;
     }
-    this.page = null;
   }
 
   async scrape(url: string, aiProvider?: AIProvider): Promise<JobData> {
@@ -364,6 +449,33 @@ export abstract class BaseScraper {
 
   // ============ Form Submission Methods ============
 
+  /**
+   * Finish a preview run: the form is filled but submit is never clicked.
+   * In a headed browser the window stays open until the user closes it, so
+   * they can check the form and submit it by hand.
+   */
+  protected async previewSubmission(errors: string[]): Promise<SubmissionResult> {
+    const config = configRepository.loadAppConfig();
+
+    let screenshotPath: string | undefined;
+    if (config.application.saveScreenshots) {
+      const { getAutoplyDir } = await import('../db');
+      screenshotPath = join(getAutoplyDir(), 'screenshots', `preview_${this.platform}_${Date.now()}.png`);
+      await this.takeScreenshot(screenshotPath).catch(() => {
+        screenshotPath = undefined;
+      });
+    }
+
+    if (this.page && !config.browser.headless) {
+      logger.newline();
+      logger.info('Form filled. Review it in the browser and submit it yourself if it looks right.');
+      logger.info('Close the browser window when you are done.');
+      await this.page.waitForEvent('close', { timeout: 0 }).catch(() => {});
+    }
+
+    return { success: true, message: PREVIEW_MESSAGE, screenshotPath, errors };
+  }
+
   /**
    * Submit an application to this platform.
    * This method initializes the browser, navigates to the job URL,
@@ -420,6 +532,10 @@ export abstract class BaseScraper {
         };
       }
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit the form
       const submitted = await this.clickSubmitButton();
       if (!submitted) {
@@ -789,6 +905,67 @@ export abstract class BaseScraper {
     return null;
   }
 
+  private async findFirst(selectors: string[]): Promise<Awaited<ReturnType<Page['$']>>> {
+    if (!this.page) return null;
+    for (const selector of selectors) {
+      const element = await this.page.$(selector).catch(() => null);
+      if (element && (await element.isVisible().catch(() => false))) return element;
+    }
+    return null;
+  }
+
+  /**
+   * Attach the cover letter using whichever the form offers: a textarea
+   * (preferred) or a file input. Returns the method used.
+   */
+  protected async attachCoverLetter(
+    options: SubmissionOptions,
+    selectors: { textarea: string[]; upload: string[] }
+  ): Promise<CoverLetterMethod> {
+    if (!this.page) return 'none';
+
+    const text = options.documents.coverLetter?.trim() ?? '';
+    const textarea = await this.findFirst(selectors.textarea);
+    // File inputs are usually hidden behind a styled button, so don't require visibility
+    let upload: Awaited<ReturnType<Page['$']>> = null;
+    for (const selector of selectors.upload) {
+      upload = await this.page.$(selector).catch(() => null);
+      if (upload) break;
+    }
+
+    const method = chooseCoverLetterMethod({
+      hasText: text.length > 0,
+      hasFile: !!options.coverLetterPath && existsSync(options.coverLetterPath),
+      textareaFound: !!textarea,
+      uploadFound: !!upload,
+    });
+
+    try {
+      if (method === 'textarea' && textarea) {
+        await textarea.fill(text);
+        await this.humanDelay(true);
+      } else if (method === 'upload' && upload) {
+        let filePath = options.coverLetterPath;
+        let tempFile: string | undefined;
+        if (!filePath || !existsSync(filePath)) {
+          tempFile = join(tmpdir(), `autoply_cover_letter_${Date.now()}.pdf`);
+          await generateCoverLetterPdf(text, tempFile, options.profile.name);
+          filePath = tempFile;
+        }
+        try {
+          await upload.setInputFiles(filePath);
+          await this.page.waitForTimeout(2000);
+          await this.humanDelay(true);
+        } finally {
+          if (tempFile && existsSync(tempFile)) unlinkSync(tempFile);
+        }
+      }
+      return method;
+    } catch {
+      return 'none';
+    }
+  }
+
   /**
    * Upload a file to a dropzone or file input.
    */
@@ -1,6 +1,8 @@
 import { BaseScraper, type SubmissionOptions, type SubmissionResult } from './base';
 import type { JobData, CustomQuestion, Platform } from '../types';
 import { FormFiller } from '../core/form-filler';
+import { parseJsonObject, asString, asStringArray } from '../ai/response';
+import { findJobPostings, jobPostingToJobData } from './json-ld';
 
 export class GenericScraper extends BaseScraper {
   platform: Platform = 'generic';
@@ -13,6 +15,30 @@ export class GenericScraper extends BaseScraper {
   protected async extractJobData(url: string): Promise<JobData> {
     if (!this.page) throw new Error('Page not initialized');
 
+    const formFields = await this.extractFormFields();
+    const customQuestions = await this.extractCustomQuestions();
+
+    // Career sites that publish schema.org JobPosting data don't need AI extraction
+    const [posting] = findJobPostings(await this.page.content());
+    if (posting) {
+      const structured = jobPostingToJobData(posting, url, this.platform);
+      if (structured.title) {
+        const description = structured.description ?? '';
+        return {
+          company: new URL(url).hostname.replace(/^www\./, ''),
+          description,
+          ...structured,
+          url,
+          platform: this.platform,
+          title: structured.title,
+          requirements: this.extractRequirements(description),
+          qualifications: this.extractQualifications(description),
+          form_fields: formFields,
+          custom_questions: customQuestions,
+        };
+      }
+    }
+
     const pageText = await this.page.evaluate(() => document.body.innerText);
     const pageTitle = await this.page.title();
 
@@ -40,15 +66,14 @@ ${pageText.slice(0, 6000)}`,
         'You extract structured job data from web pages. Return valid JSON only, no markdown fences.'
       );
 
-      const cleaned = response.replace(/```json?\n?/g, '').replace(/```/g, '').trim();
-      const parsed = JSON.parse(cleaned);
+      const parsed = parseJsonObject(response);
 
-      title = parsed.title || title;
-      company = parsed.company || company;
-      description = parsed.description || description;
-      requirements = parsed.requirements || [];
-      qualifications = parsed.qualifications || [];
-      location = parsed.location;
+      title = asString(parsed.title) ?? title;
+      company = asString(parsed.company) ?? company;
+      description = asString(parsed.description) ?? description;
+      requirements = asStringArray(parsed.requirements);
+      qualifications = asStringArray(parsed.qualifications);
+      location = asString(parsed.location);
     } catch {
       // Fall back to basic extraction
       const h1Text = await this.extractText('h1');
@@ -57,9 +82,6 @@ ${pageText.slice(0, 6000)}`,
       qualifications = this.extractQualifications(description);
     }
 
-    const formFields = await this.extractFormFields();
-    const customQuestions = await this.extractCustomQuestions();
-
     return {
       url,
       platform: this.platform,
@@ -118,6 +140,10 @@ ${pageText.slice(0, 6000)}`,
 
       await this.humanDelay(true);
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickSubmitButton();
       if (!submitted) {
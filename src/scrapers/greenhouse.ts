@@ -3,6 +3,7 @@ import type { JobData, CustomQuestion, Platform, Profile } from '../types';
 import { FormFiller } from '../core/form-filler';
 import { analyzeAndFillFormFields } from '../ai/form-analyzer';
 import { createAIProvider } from '../ai/provider';
+import { splitName, preferredFirstName } from '../utils/name';
 
 export class GreenhouseScraper extends BaseScraper {
   platform: Platform = 'greenhouse';
@@ -100,7 +101,7 @@ export class GreenhouseScraper extends BaseScraper {
 
       // Navigate to job posting
       await this.humanDelay();
-      await this.page.goto(resolvedUrl, { waitUntil: 'domcontentloaded', timeout: 60000 });
+      await this.page.goto(resolvedUrl, { waitUntil: 'domcontentloaded', timeout: this.pageTimeout * 2 });
       await this.waitForContent();
       await this.humanDelay(true);
       await this.humanScroll();
@@ -137,10 +138,20 @@ export class GreenhouseScraper extends BaseScraper {
         }
       }
 
-      // Upload cover letter if available
-      if (options.coverLetterPath) {
-        await this.uploadGreenhouseCoverLetter(options.coverLetterPath);
-      }
+      // Paste the cover letter, or upload it if the form only takes a file
+      await this.attachCoverLetter(options, {
+        textarea: [
+          '#cover_letter_text',
+          'textarea[name*="cover_letter"]',
+          'textarea[id*="cover_letter"]',
+        ],
+        upload: [
+          '#cover_letter_upload input[type="file"]',
+          '#s3_upload_for_cover_letter input[type="file"]',
+          'input[type="file"][name*="cover"]',
+          '[data-field="cover_letter"] input[type="file"]',
+        ],
+      });
 
       // Fill LinkedIn/Website fields
       await this.fillGreenhouseUrls(options);
@@ -250,6 +261,10 @@ export class GreenhouseScraper extends BaseScraper {
         console.log('[Greenhouse Debug] Empty fields before submit:', JSON.stringify(emptyFields, null, 2));
       }
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickGreenhouseSubmit();
       if (!submitted) {
@@ -297,11 +312,9 @@ export class GreenhouseScraper extends BaseScraper {
 
     const { profile } = options;
 
-    // First name
-    await this.fillInputBySelector('#first_name, input[name="job_application[first_name]"]', profile.name.split(' ')[0]);
-
-    // Last name
-    const lastName = profile.name.split(' ').slice(1).join(' ');
+    // First and last name
+    const { first: firstName, last: lastName } = splitName(profile.name);
+    await this.fillInputBySelector('#first_name, input[name="job_application[first_name]"]', firstName);
     await this.fillInputBySelector('#last_name, input[name="job_application[last_name]"]', lastName);
 
     // Email
@@ -831,33 +844,6 @@ export class GreenhouseScraper extends BaseScraper {
     }
   }
 
-  private async uploadGreenhouseCoverLetter(coverLetterPath: string): Promise<boolean> {
-    if (!this.page) return false;
-
-    try {
-      const coverLetterSelectors = [
-        '#cover_letter_upload input[type="file"]',
-        '#s3_upload_for_cover_letter input[type="file"]',
-        'input[type="file"][name*="cover"]',
-        '[data-field="cover_letter"] input[type="file"]',
-      ];
-
-      for (const selector of coverLetterSelectors) {
-        const fileInput = await this.page.$(selector);
-        if (fileInput) {
-          await fileInput.setInputFiles(coverLetterPath);
-          await this.page.waitForTimeout(2000);
-          await this.humanDelay(true);
-          return true;
-        }
-      }
-
-      return false;
-    } catch {
-      return false;
-    }
-  }
-
   private async fillGreenhouseUrls(options: SubmissionOptions): Promise<void> {
     if (!this.page) return;
 
@@ -1399,7 +1385,7 @@ export class GreenhouseScraper extends BaseScraper {
     if (!this.page) return;
 
     try {
-      const firstName = profile.name.split(' ')[0];
+      const firstName = preferredFirstName(profile.name);
 
       // Try by label text
       const labels = await this.page.$$('label');
@@ -1575,7 +1561,7 @@ export class GreenhouseScraper extends BaseScraper {
           } else if (combined.includes('location') || combined.includes('city')) {
             value = profile.location || 'Lagos, Nigeria';
           } else if (combined.includes('preferred') && combined.includes('name')) {
-            value = profile.name.split(' ')[0];
+            value = preferredFirstName(profile.name);
           } else if (combined.includes('phone') || combined.includes('tel')) {
             value = profile.phone || '';
           } else if (combined.includes('salary') || combined.includes('compensation') || combined.includes('pay')) {
@@ -33,6 +33,10 @@ export class JobviteScraper extends BaseScraper {
       // Fill form
       await this.fillJobviteForm(options, errors);
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickSubmit();
       if (!submitted) {
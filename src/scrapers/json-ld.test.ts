@@ -0,0 +1,60 @@
+import { describe, expect, test } from 'bun:test';
+import { readFileSync } from 'fs';
+import { join } from 'path';
+import { findJobPostings, htmlToText, jobPostingToJobData } from './json-ld';
+
+const fixture = readFileSync(join(import.meta.dir, '__fixtures__', 'json-ld.html'), 'utf-8');
+
+function page(...blocks: unknown[]): string {
+  return blocks.map((block) => `<script type="application/ld+json">${JSON.stringify(block)}</script>`).join('\n');
+}
+
+describe('findJobPostings', () => {
+  test('finds postings inside @graph and skips broken blocks', () => {
+    const postings = findJobPostings(fixture);
+    expect(postings).toHaveLength(1);
+    expect(postings[0].title).toBe('Senior Backend Engineer');
+  });
+
+  test('handles top-level arrays and multi-valued @type', () => {
+    const html = page([{ '@type': 'WebPage' }, { '@type': ['JobPosting', 'Thing'], title: 'Designer' }]);
+    expect(findJobPostings(html).map((p) => p.title)).toEqual(['Designer']);
+  });
+
+  test('returns nothing when the page has no JobPosting', () => {
+    expect(findJobPostings(page({ '@type': 'Organization', name: 'Acme' }))).toEqual([]);
+    expect(findJobPostings('<html></html>')).toEqual([]);
+  });
+});
+
+describe('jobPostingToJobData', () => {
+  test('maps the fixture posting', () => {
+    const [posting] = findJobPostings(fixture);
+    const data = jobPostingToJobData(posting, 'https://initech.com/careers/42', 'generic');
+
+    expect(data.title).toBe('Senior Backend Engineer');
+    expect(data.company).toBe('Initech');
+    expect(data.location).toBe('Austin, TX, US');
+    expect(data.salary).toBe('USD 150000 - 180000 per year');
+    expect(data.job_type).toBe('full-time');
+    expect(data.description).toContain('- 5+ years of Go or TypeScript');
+    expect(data.description).not.toContain('<li>');
+  });
+
+  test('treats TELECOMMUTE postings as remote', () => {
+    const data = jobPostingToJobData(
+      { '@type': 'JobPosting', title: 'SRE', jobLocationType: 'TELECOMMUTE', hiringOrganization: 'Acme' },
+      'https://acme.com/jobs/1',
+      'generic'
+    );
+    expect(data.remote).toBe(true);
+    expect(data.location).toBe('Remote');
+    expect(data.company).toBe('Acme');
+  });
+});
+
+describe('htmlToText', () => {
+  test('keeps line structure and decodes entities', () => {
+    expect(htmlToText('<p>R&amp;D</p><p>Tea &nbsp;time</p>')).toBe('R&D\nTea  time');
+  });
+});
@@ -0,0 +1,121 @@
+import type { JobData, Platform } from '../types';
+
+type JsonObject = Record<string, unknown>;
+
+const SCRIPT_PATTERN = /<script[^>]*type\s*=\s*["']application\/ld\+json["'][^>]*>([\s\S]*?)<\/script>/gi;
+
+function isObject(value: unknown): value is JsonObject {
+  return typeof value === 'object' && value !== null && !Array.isArray(value);
+}
+
+function text(value: unknown): string | undefined {
+  if (typeof value === 'string') return value.trim() || undefined;
+  if (typeof value === 'number') return String(value);
+  return undefined;
+}
+
+function hasType(node: JsonObject, type: string): boolean {
+  const value = node['@type'];
+  return Array.isArray(value) ? value.includes(type) : value === type;
+}
+
+/** Decode the handful of entities that show up in posting descriptions, then drop tags. */
+export function htmlToText(html: string): string {
+  return html
+    .replace(/<\s*br\s*\/?>/gi, '\n')
+    .replace(/<\/(p|div|li|h[1-6])>/gi, '\n')
+    .replace(/<li[^>]*>/gi, '- ')
+    .replace(/<[^>]+>/g, '')
+    .replace(/&nbsp;/g, ' ')
+    .replace(/&amp;/g, '&')
+    .replace(/&lt;/g, '<')
+    .replace(/&gt;/g, '>')
+    .replace(/&quot;/g, '"')
+    .replace(/&#39;|&apos;/g, "'")
+    .replace(/[ \t]+\n/g, '\n')
+    .replace(/\n{3,}/g, '\n\n')
+    .trim();
+}
+
+/** Every JobPosting node in the page's JSON-LD, including ones nested in @graph or arrays. */
+export function findJobPostings(html: string): JsonObject[] {
+  const postings: JsonObject[] = [];
+
+  const visit = (node: unknown): void => {
+    if (Array.isArray(node)) {
+      node.forEach(visit);
+      return;
+    }
+    if (!isObject(node)) return;
+    if (hasType(node, 'JobPosting')) postings.push(node);
+    if (node['@graph']) visit(node['@graph']);
+  };
+
+  for (const match of html.matchAll(SCRIPT_PATTERN)) {
+    try {
+      visit(JSON.parse(match[1].trim()));
+    } catch {
+      // Sites ship broken JSON-LD often enough; skip the block
+    }
+  }
+  return postings;
+}
+
+function formatLocation(posting: JsonObject): string | undefined {
+  const locations = Array.isArray(posting.jobLocation) ? posting.jobLocation : [posting.jobLocation];
+  const parts = locations
+    .map((location) => {
+      const address = isObject(location) && isObject(location.address) ? location.address : undefined;
+      if (!address) return undefined;
+      const country = isObject(address.addressCountry) ? text(address.addressCountry.name) : text(address.addressCountry);
+      return [text(address.addressLocality), text(address.addressRegion), country].filter(Boolean).join(', ');
+    })
+    .filter((value): value is string => Boolean(value));
+  return parts.length > 0 ? [...new Set(parts)].join('; ') : undefined;
+}
+
+function formatSalary(posting: JsonObject): string | undefined {
+  const salary = posting.baseSalary;
+  if (!isObject(salary)) return text(salary);
+  const currency = text(salary.currency) ?? '';
+  const value = isObject(salary.value) ? salary.value : undefined;
+  if (!value) return text(salary.value) ? `${currency} ${text(salary.value)}`.trim() : undefined;
+
+  const amount = text(value.value);
+  const min = text(value.minValue) ?? amount;
+  const max = text(value.maxValue) ?? amount;
+  if (!min && !max) return undefined;
+
+  const unit = text(value.unitText)?.toLowerCase();
+  const per = unit === 'hour' ? ' per hour' : unit === 'month' ? ' per month' : unit === 'year' ? ' per year' : '';
+  const range = min && max && min !== max ? `${min} - ${max}` : (min ?? max)!;
+  return `${currency} ${range}${per}`.trim();
+}
+
+/**
+ * Map a schema.org JobPosting onto JobData. Form fields and questions are
+ * left empty; they come from the page itself.
+ */
+export function jobPostingToJobData(posting: JsonObject, url: string, platform: Platform): Partial<JobData> {
+  const organization = isObject(posting.hiringOrganization) ? text(posting.hiringOrganization.name) : text(posting.hiringOrganization);
+  const employmentType = Array.isArray(posting.employmentType)
+    ? posting.employmentType.map(text).filter(Boolean).join(', ')
+    : text(posting.employmentType);
+  const remote = text(posting.jobLocationType)?.toUpperCase() === 'TELECOMMUTE' ? true : undefined;
+
+  const result: Partial<JobData> = { url, platform };
+  const title = text(posting.title);
+  const description = text(posting.description);
+  const location = formatLocation(posting);
+  const salary = formatSalary(posting);
+
+  if (title) result.title = title;
+  if (organization) result.company = organization;
+  if (description) result.description = htmlToText(description);
+  if (location) result.location = location;
+  else if (remote) result.location = 'Remote';
+  if (salary) result.salary = salary;
+  if (employmentType) result.job_type = employmentType.toLowerCase().replace(/_/g, '-');
+  if (remote) result.remote = true;
+  return result;
+}
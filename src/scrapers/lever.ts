@@ -101,10 +101,15 @@ export class LeverScraper extends BaseScraper {
         }
       }
 
-      // Upload cover letter
-      if (options.coverLetterPath) {
-        await this.uploadLeverCoverLetter(options.coverLetterPath);
-      }
+      // Paste the cover letter, or upload it if the form only takes a file
+      const coverLetterMethod = await this.attachCoverLetter(options, {
+        textarea: ['textarea[name*="cover"]', '[class*="cover-letter"] textarea'],
+        upload: [
+          'input[type="file"][name*="cover"]',
+          '[class*="cover-letter"] input[type="file"]',
+          '#cover-letter-upload input[type="file"]',
+        ],
+      });
 
       // Fill URLs
       await this.fillLeverUrls(options);
@@ -117,8 +122,10 @@ export class LeverScraper extends BaseScraper {
         }
       }
 
-      // Handle additional info textarea if present
-      await this.fillLeverAdditionalInfo(options);
+      // Point reviewers at the attached letter when it went in as a file
+      if (coverLetterMethod === 'upload') {
+        await this.fillLeverAdditionalInfo(options);
+      }
 
       // Validate
       const validation = await this.validateBeforeSubmit();
@@ -126,6 +133,10 @@ export class LeverScraper extends BaseScraper {
         errors.push(...validation.errors);
       }
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickLeverSubmit();
       if (!submitted) {
@@ -272,33 +283,6 @@ export class LeverScraper extends BaseScraper {
     }
   }
 
-  private async uploadLeverCoverLetter(coverLetterPath: string): Promise<boolean> {
-    if (!this.page) return false;
-
-    try {
-      // Lever may have a separate cover letter upload
-      const coverLetterSelectors = [
-        'input[type="file"][name*="cover"]',
-        '[class*="cover-letter"] input[type="file"]',
-        '#cover-letter-upload input[type="file"]',
-      ];
-
-      for (const selector of coverLetterSelectors) {
-        const fileInput = await this.page.$(selector);
-        if (fileInput) {
-          await fileInput.setInputFiles(coverLetterPath);
-          await this.page.waitForTimeout(2000);
-          await this.humanDelay(true);
-          return true;
-        }
-      }
-
-      return false;
-    } catch {
-      return false;
-    }
-  }
-
   private async fillLeverUrls(options: SubmissionOptions): Promise<void> {
     if (!this.page) return;
 
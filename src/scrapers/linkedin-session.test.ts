@@ -0,0 +1,134 @@
+import { describe, expect, test, afterEach } from 'bun:test';
+import { mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { tmpdir } from 'os';
+import { join } from 'path';
+import {
+  checkLinkedInSession,
+  classifyLinkedInJobPage,
+  classifyLinkedInUrl,
+  hasLinkedInSession,
+  readStorageState,
+  saveStorageState,
+  writeStorageState,
+  type StorageState,
+} from './linkedin-session';
+
+const NOW = Date.UTC(2026, 0, 1);
+
+function stateWith(expires: number, name = 'li_at'): StorageState {
+  return {
+    cookies: [{ name, value: 'token', domain: '.www.linkedin.com', path: '/', expires, httpOnly: true, secure: true, sameSite: 'None' }],
+    origins: [],
+  };
+}
+
+let tempDir: string | undefined;
+
+afterEach(() => {
+  if (tempDir) rmSync(tempDir, { recursive: true, force: true });
+  tempDir = undefined;
+});
+
+describe('storage state', () => {
+  test('saves a browser context so the session reads back as logged in', async () => {
+    tempDir = mkdtempSync(join(tmpdir(), 'autoply-session-'));
+    const path = join(tempDir, 'nested', 'browser-state.json');
+    const state = stateWith(NOW / 1000 + 3600);
+    const context = { storageState: async () => state };
+
+    await saveStorageState(context, path);
+
+    expect(readStorageState(path)).toEqual(state);
+    expect(hasLinkedInSession(readStorageState(path), NOW)).toBe(true);
+  });
+
+  test('missing or malformed files read as null', () => {
+    tempDir = mkdtempSync(join(tmpdir(), 'autoply-session-'));
+    const path = join(tempDir, 'browser-state.json');
+    expect(readStorageState(path)).toBeNull();
+
+    writeFileSync(path, '{"cookies": "nope"}');
+    expect(readStorageState(path)).toBeNull();
+  });
+});
+
+describe('hasLinkedInSession', () => {
+  test('accepts an unexpired or session li_at cookie', () => {
+    expect(hasLinkedInSession(stateWith(NOW / 1000 + 3600), NOW)).toBe(true);
+    expect(hasLinkedInSession(stateWith(-1), NOW)).toBe(true);
+  });
+
+  test('rejects expired, missing or unrelated cookies', () => {
+    expect(hasLinkedInSession(stateWith(NOW / 1000 - 1), NOW)).toBe(false);
+    expect(hasLinkedInSession(stateWith(NOW / 1000 + 3600, 'bcookie'), NOW)).toBe(false);
+    expect(hasLinkedInSession(null, NOW)).toBe(false);
+  });
+});
+
+describe('classifyLinkedInUrl', () => {
+  test('detects checkpoints and login redirects', () => {
+    expect(classifyLinkedInUrl('https://www.linkedin.com/checkpoint/challenge/AgE')).toBe('checkpoint');
+    expect(classifyLinkedInUrl('https://www.linkedin.com/login?session_redirect=x')).toBe('login');
+    expect(classifyLinkedInUrl('https://www.linkedin.com/uas/login')).toBe('login');
+    expect(classifyLinkedInUrl('https://www.linkedin.com/authwall?trk=x')).toBe('login');
+    expect(classifyLinkedInUrl('https://www.linkedin.com/jobs/view/123')).toBe('ok');
+  });
+});
+
+describe('classifyLinkedInJobPage', () => {
+  const job = 'https://www.linkedin.com/jobs/view/123';
+
+  test('a page with content is a job', () => {
+    expect(classifyLinkedInJobPage({ url: job, hasContent: true, hasClosedNotice: false, hasAuthwall: false })).toBe('job');
+  });
+
+  test('a closed posting is distinguished from a block', () => {
+    expect(classifyLinkedInJobPage({ url: job, hasContent: true, hasClosedNotice: true, hasAuthwall: false })).toBe('closed');
+  });
+
+  test('authwalls, checkpoints and empty shells are blocked', () => {
+    expect(classifyLinkedInJobPage({ url: job, hasContent: true, hasClosedNotice: false, hasAuthwall: true })).toBe('blocked');
+    expect(
+      classifyLinkedInJobPage({ url: 'https://www.linkedin.com/authwall?trk=x', hasContent: false, hasClosedNotice: false, hasAuthwall: false })
+    ).toBe('blocked');
+    expect(
+      classifyLinkedInJobPage({ url: 'https://www.linkedin.com/checkpoint/challenge/x', hasContent: false, hasClosedNotice: false, hasAuthwall: false })
+    ).toBe('blocked');
+    expect(classifyLinkedInJobPage({ url: job, hasContent: false, hasClosedNotice: false, hasAuthwall: false })).toBe('blocked');
+  });
+});
+
+describe('checkLinkedInSession', () => {
+  function savedState(expires: number): string {
+    tempDir = mkdtempSync(join(tmpdir(), 'autoply-li-check-'));
+    const path = join(tempDir, 'browser-state.json');
+    writeStorageState(path, stateWith(expires));
+    return path;
+  }
+
+  const visitTo = (finalUrl: string) => async () => finalUrl;
+
+  test('reports a missing session without opening a browser', async () => {
+    let visited = false;
+    const visit = async () => {
+      visited = true;
+      return '';
+    };
+
+    expect(await checkLinkedInSession(undefined, visit, NOW)).toBe('missing');
+    expect(await checkLinkedInSession('/nonexistent/state.json', visit, NOW)).toBe('missing');
+    expect(visited).toBe(false);
+  });
+
+  test('reports an expired cookie', async () => {
+    expect(await checkLinkedInSession(savedState(NOW / 1000 - 60), visitTo(''), NOW)).toBe('expired');
+  });
+
+  test('classifies where the feed page lands', async () => {
+    const path = savedState(-1);
+
+    expect(await checkLinkedInSession(path, visitTo('https://www.linkedin.com/feed/'), NOW)).toBe('ok');
+    expect(await checkLinkedInSession(path, visitTo('https://www.linkedin.com/checkpoint/challenge/abc'), NOW)).toBe('checkpoint');
+    expect(await checkLinkedInSession(path, visitTo('https://www.linkedin.com/login?session_redirect=x'), NOW)).toBe('login');
+  });
+});
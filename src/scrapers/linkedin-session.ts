@@ -0,0 +1,135 @@
+import { existsSync, mkdirSync, readFileSync, writeFileSync } from 'fs';
+import { dirname } from 'path';
+
+/** The subset of Playwright's storage state that we read and write. */
+export interface StoredCookie {
+  name: string;
+  value: string;
+  domain: string;
+  path: string;
+  /** Unix time in seconds; -1 for session cookies */
+  expires: number;
+  httpOnly?: boolean;
+  secure?: boolean;
+  sameSite?: 'Strict' | 'Lax' | 'None';
+}
+
+export interface StorageState {
+  cookies: StoredCookie[];
+  origins: unknown[];
+}
+
+export type LinkedInPageState = 'ok' | 'login' | 'checkpoint';
+
+/** What a scraped LinkedIn job URL actually showed. */
+export type LinkedInJobPageState = 'job' | 'closed' | 'blocked';
+
+export interface LinkedInJobPageSignals {
+  /** The page URL after redirects */
+  url: string;
+  /** A job title or description was found */
+  hasContent: boolean;
+  /** "No longer accepting applications" banner */
+  hasClosedNotice: boolean;
+  /** Sign-in/join overlay shown to logged-out visitors */
+  hasAuthwall: boolean;
+}
+
+/** LinkedIn showed a login wall, checkpoint or empty shell instead of the posting. */
+export class LinkedInBlockedError extends Error {
+  constructor() {
+    super('LinkedIn showed a sign-in or verification page instead of the job. Run "autoply login linkedin" and try again.');
+    this.name = 'LinkedInBlockedError';
+  }
+}
+
+/** LinkedIn's auth cookie; without it every request lands on the login page. */
+const SESSION_COOKIE = 'li_at';
+
+export function readStorageState(path: string): StorageState | null {
+  if (!existsSync(path)) return null;
+  try {
+    const parsed = JSON.parse(readFileSync(path, 'utf-8')) as Partial<StorageState>;
+    if (!Array.isArray(parsed.cookies)) return null;
+    return { cookies: parsed.cookies, origins: Array.isArray(parsed.origins) ? parsed.origins : [] };
+  } catch {
+    return null;
+  }
+}
+
+export function writeStorageState(path: string, state: StorageState): void {
+  mkdirSync(dirname(path), { recursive: true });
+  writeFileSync(path, JSON.stringify(state, null, 2));
+}
+
+/**
+ * Save a browser context's cookies and local storage to path, creating the
+ * directory if needed. Used after login and whenever the scraper refreshes
+ * the session.
+ */
+export async function saveStorageState(
+  context: { storageState(): Promise<StorageState> },
+  path: string
+): Promise<StorageState> {
+  const state = await context.storageState();
+  writeStorageState(path, state);
+  return state;
+}
+
+/** True when the saved state still holds an unexpired LinkedIn auth cookie. */
+export function hasLinkedInSession(state: StorageState | null, now = Date.now()): boolean {
+  if (!state) return false;
+  return state.cookies.some(
+    (cookie) =>
+      cookie.name === SESSION_COOKIE &&
+      cookie.domain.endsWith('linkedin.com') &&
+      cookie.value.length > 0 &&
+      (cookie.expires === -1 || cookie.expires * 1000 > now)
+  );
+}
+
+/**
+ * Where LinkedIn sent us. Security checkpoints live under /checkpoint/ and
+ * usually follow too many fresh logins; the login page means the session expired.
+ */
+export function classifyLinkedInUrl(url: string): LinkedInPageState {
+  let path: string;
+  try {
+    path = new URL(url).pathname;
+  } catch {
+    return 'ok';
+  }
+  if (/^\/(checkpoint|challenge)\b/.test(path)) return 'checkpoint';
+  if (/^\/(login|uas\/login|signup|authwall)\b/.test(path)) return 'login';
+  return 'ok';
+}
+
+/**
+ * Tell a real posting apart from a block. A page with neither title nor
+ * description is treated as blocked: LinkedIn serves an empty shell when it throttles.
+ */
+export function classifyLinkedInJobPage(signals: LinkedInJobPageSignals): LinkedInJobPageState {
+  if (classifyLinkedInUrl(signals.url) !== 'ok' || signals.hasAuthwall) return 'blocked';
+  if (!signals.hasContent) return 'blocked';
+  return signals.hasClosedNotice ? 'closed' : 'job';
+}
+
+export const LINKEDIN_FEED_URL = 'https://www.linkedin.com/feed/';
+
+export type LinkedInSessionCheck = 'missing' | 'expired' | LinkedInPageState;
+
+/**
+ * Check a saved session without applying to anything: first the cookie
+ * on disk, then where LinkedIn sends the feed page. visit loads a URL with
+ * the session and resolves to the final URL after redirects.
+ */
+export async function checkLinkedInSession(
+  storagePath: string | undefined,
+  visit: (url: string) => Promise<string>,
+  now = Date.now()
+): Promise<LinkedInSessionCheck> {
+  const state = storagePath ? readStorageState(storagePath) : null;
+  if (!state) return 'missing';
+  if (!hasLinkedInSession(state, now)) return 'expired';
+  return classifyLinkedInUrl(await visit(LINKEDIN_FEED_URL));
+}
@@ -1,6 +1,20 @@
 import { BaseScraper, type SubmissionOptions, type SubmissionResult } from './base';
 import type { JobData, CustomQuestion, Platform } from '../types';
 import { FormFiller } from '../core/form-filler';
+import { isRemoteJob } from '../utils/location';
+import { configRepository } from '../db/repositories/config';
+import { logger } from '../utils/logger';
+import {
+  classifyLinkedInJobPage,
+  classifyLinkedInUrl,
+  hasLinkedInSession,
+  LinkedInBlockedError,
+  readStorageState,
+  saveStorageState,
+} from './linkedin-session';
+
+const LOGIN_REQUIRED_MESSAGE = 'Not logged in to LinkedIn. Run "autoply login" first to save your session.';
+const CHECKPOINT_WAIT_MS = 5 * 60 * 1000;
 
 export class LinkedInScraper extends BaseScraper {
   platform: Platform = 'linkedin';
@@ -21,6 +35,16 @@ export class LinkedInScraper extends BaseScraper {
   override async submitApplication(url: string, options: SubmissionOptions): Promise<SubmissionResult> {
     const errors: string[] = [];
 
+    // Don't open a browser just to hit the login wall with an expired session
+    const storagePath = configRepository.loadAppConfig().browser.storageState;
+    if (!storagePath || !hasLinkedInSession(readStorageState(storagePath))) {
+      return {
+        success: false,
+        message: LOGIN_REQUIRED_MESSAGE,
+        errors: ['LinkedIn login required'],
+      };
+    }
+
     try {
       await this.initialize();
       if (!this.page) throw new Error('Browser not initialized');
@@ -28,19 +52,31 @@ export class LinkedInScraper extends BaseScraper {
       // Navigate to job posting
       await this.humanDelay();
       await this.page.goto(url, { waitUntil: 'networkidle' });
+
+      if (classifyLinkedInUrl(this.page.url()) === 'checkpoint' && !(await this.waitForCheckpoint(url))) {
+        return {
+          success: false,
+          message: 'LinkedIn is showing a security checkpoint. Run "autoply login" and complete it in the browser.',
+          errors: ['LinkedIn security checkpoint'],
+        };
+      }
+
       await this.humanDelay(true);
       await this.humanScroll();
 
       // Check if logged in
-      const isLoggedIn = await this.checkLinkedInLogin();
+      const isLoggedIn = classifyLinkedInUrl(this.page.url()) === 'ok' && await this.checkLinkedInLogin();
       if (!isLoggedIn) {
         return {
           success: false,
-          message: 'Not logged in to LinkedIn. Run "autoply login" first to save your session.',
+          message: LOGIN_REQUIRED_MESSAGE,
           errors: ['LinkedIn login required'],
         };
       }
 
+      // LinkedIn rotates its cookies; keep the saved session current so the next run doesn't log in again
+      await this.persistSession(storagePath);
+
       // Check if Easy Apply is available
       const hasEasyApply = await this.hasEasyApplyButton();
       if (!hasEasyApply) {
@@ -60,7 +96,6 @@ export class LinkedInScraper extends BaseScraper {
       errors.push(...result.errors);
 
       // Take screenshot
-      const { configRepository } = await import('../db/repositories/config');
       const config = configRepository.loadAppConfig();
       let screenshotPath: string | undefined;
       if (config.application.saveScreenshots) {
@@ -88,6 +123,34 @@ export class LinkedInScraper extends BaseScraper {
     }
   }
 
+  /**
+   * In a visible browser, give the user a chance to clear the checkpoint
+   * instead of failing the application. Headless runs can't, so they give up.
+   */
+  private async waitForCheckpoint(jobUrl: string): Promise<boolean> {
+    if (!this.page || configRepository.loadAppConfig().browser.headless) return false;
+
+    logger.warning('LinkedIn security checkpoint - complete it in the browser window to continue...');
+    try {
+      await this.page.waitForURL((next) => classifyLinkedInUrl(next.toString()) !== 'checkpoint', {
+        timeout: CHECKPOINT_WAIT_MS,
+      });
+      await this.page.goto(jobUrl, { waitUntil: 'networkidle' });
+      return classifyLinkedInUrl(this.page.url()) === 'ok';
+    } catch {
+      return false;
+    }
+  }
+
+  private async persistSession(path: string): Promise<void> {
+    if (!this.context) return;
+    try {
+      await saveStorageState(this.context, path);
+    } catch (error) {
+      logger.debug(`Could not refresh LinkedIn session: ${error instanceof Error ? error.message : error}`);
+    }
+  }
+
   private async checkLinkedInLogin(): Promise<boolean> {
     if (!this.page) return false;
 
@@ -202,6 +265,10 @@ export class LinkedInScraper extends BaseScraper {
         const isEnabled = await submitButton.isEnabled();
 
         if (isVisible && isEnabled) {
+          if (options.preview) {
+            return this.previewSubmission(errors);
+          }
+
           await this.humanDelay(true);
           await submitButton.click();
 
@@ -630,13 +697,28 @@ export class LinkedInScraper extends BaseScraper {
       '.jobs-description-content__text, .jobs-box__html-content, .description__text'
     );
 
+    const pageState = classifyLinkedInJobPage({
+      url: this.page.url(),
+      hasContent: title.trim().length > 0 || description.trim().length > 0,
+      hasClosedNotice: (await this.extractText('.jobs-details-top-card__apply-error, .artdeco-inline-feedback--error'))
+        .toLowerCase()
+        .includes('no longer accepting'),
+      hasAuthwall: (await this.page.$('.authwall-join-form')) !== null,
+    });
+    if (pageState === 'blocked') {
+      throw new LinkedInBlockedError();
+    }
+    if (pageState === 'closed') {
+      throw new Error('This LinkedIn job is no longer accepting applications.');
+    }
+
     // Extract job type
     const jobType = await this.extractText(
       '.job-details-jobs-unified-top-card__job-insight, .jobs-unified-top-card__workplace-type'
     );
 
     // Check if remote
-    const remote = jobType.toLowerCase().includes('remote') || location.toLowerCase().includes('remote');
+    const remote = isRemoteJob({ location, job_type: jobType });
 
     // Form fields for LinkedIn are typically handled through their Easy Apply flow
     const formFields = await this.extractFormFields();
@@ -33,6 +33,10 @@ export class PinpointScraper extends BaseScraper {
       // Fill form
       await this.fillPinpointForm(options, errors);
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickSubmit();
       if (!submitted) {
@@ -1,6 +1,7 @@
 import { BaseScraper, type SubmissionOptions, type SubmissionResult } from './base';
 import type { JobData, CustomQuestion, Platform } from '../types';
 import { FormFiller } from '../core/form-filler';
+import { splitName } from '../utils/name';
 
 export class SmartRecruitersScraper extends BaseScraper {
   platform: Platform = 'smartrecruiters';
@@ -33,6 +34,10 @@ export class SmartRecruitersScraper extends BaseScraper {
       // Fill form
       await this.fillSmartRecruitersForm(options, errors);
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickSubmit();
       if (!submitted) {
@@ -96,8 +101,9 @@ export class SmartRecruitersScraper extends BaseScraper {
     });
 
     // Fill basic fields
-    await this.fillInput('input[name*="firstName"]', profile.name.split(' ')[0]);
-    await this.fillInput('input[name*="lastName"]', profile.name.split(' ').slice(1).join(' '));
+    const { first, last } = splitName(profile.name);
+    await this.fillInput('input[name*="firstName"]', first);
+    await this.fillInput('input[name*="lastName"]', last);
     await this.fillInput('input[name*="email"], input[type="email"]', profile.email);
     if (profile.phone) {
       await this.fillInput('input[name*="phone"], input[type="tel"]', profile.phone);
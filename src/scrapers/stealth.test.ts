@@ -0,0 +1,76 @@
+import { describe, expect, test } from 'bun:test';
+import { applyStealth, browserContextOptions, browserLaunchArgs, stealthInitScript, DEFAULT_USER_AGENT, type InitScriptTarget } from './stealth';
+
+function recordingTarget(): InitScriptTarget & { scripts: Array<() => void> } {
+  const scripts: Array<() => void> = [];
+  return {
+    scripts,
+    addInitScript: async (script) => {
+      scripts.push(script);
+    },
+  };
+}
+
+describe('applyStealth', () => {
+  test('registers the stealth script once', async () => {
+    const target = recordingTarget();
+
+    expect(await applyStealth(target)).toBe(true);
+    expect(target.scripts).toEqual([stealthInitScript]);
+  });
+
+  test('does nothing when disabled in config', async () => {
+    const target = recordingTarget();
+
+    expect(await applyStealth(target, false)).toBe(false);
+    expect(target.scripts).toHaveLength(0);
+  });
+
+  test('the script masks webdriver, plugins, languages and chrome', () => {
+    const source = stealthInitScript.toString();
+    for (const signal of ['webdriver', 'plugins', 'languages', 'chrome']) {
+      expect(source).toContain(signal);
+    }
+  });
+});
+
+describe('browserLaunchArgs', () => {
+  test('adds the automation flag only with stealth on', () => {
+    expect(browserLaunchArgs(true)).toContain('--disable-blink-features=AutomationControlled');
+    expect(browserLaunchArgs(false)).not.toContain('--disable-blink-features=AutomationControlled');
+  });
+
+  test('always disables site isolation for iframe scraping', () => {
+    expect(browserLaunchArgs(false)).toEqual(['--disable-features=IsolateOrigins,site-per-process']);
+  });
+});
+
+describe('browserContextOptions', () => {
+  test('defaults to a desktop Chrome user agent and no extra headers', () => {
+    const options = browserContextOptions({});
+
+    expect(options.userAgent).toBe(DEFAULT_USER_AGENT);
+    expect(options.userAgent).not.toContain('Autoply');
+    expect(options.extraHTTPHeaders).toBeUndefined();
+  });
+
+  test('uses the configured user agent and headers', () => {
+    const options = browserContextOptions({
+      userAgent: 'Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0',
+      headers: { 'Accept-Language': 'en-GB,en;q=0.9' },
+    });
+
+    expect(options.userAgent).toBe('Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0');
+    expect(options.extraHTTPHeaders).toEqual({ 'Accept-Language': 'en-GB,en;q=0.9' });
+  });
+
+  test('ignores a blank user agent and malformed headers', () => {
+    const options = browserContextOptions({
+      userAgent: '  ',
+      headers: { '': 'x', 'X-Count': 3 as unknown as string },
+    });
+
+    expect(options.userAgent).toBe(DEFAULT_USER_AGENT);
+    expect(options.extraHTTPHeaders).toBeUndefined();
+  });
+});
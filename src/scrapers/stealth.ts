@@ -0,0 +1,89 @@
+/**
+ * Chromium flags for every autoply browser. Site isolation is always off so
+ * scrapers can reach into cross-origin iframes (embedded Greenhouse boards);
+ * the AutomationControlled flag is only dropped when stealth is on.
+ */
+export function browserLaunchArgs(stealth: boolean): string[] {
+  const args = ['--disable-features=IsolateOrigins,site-per-process'];
+  if (stealth) args.unshift('--disable-blink-features=AutomationControlled');
+  return args;
+}
+
+export const DEFAULT_USER_AGENT =
+  'Mozilla/5.0 (Macintosh; Apple Silicon Mac OS X 14_0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36';
+
+export interface ContextOptions {
+  userAgent: string;
+  extraHTTPHeaders?: Record<string, string>;
+  viewport: { width: number; height: number };
+  locale: string;
+  timezoneId: string;
+}
+
+/**
+ * Browser context settings shared by the scrapers and "autoply login", so a
+ * saved session comes from a browser that presents the same way.
+ */
+export function browserContextOptions(browser: { userAgent?: string; headers?: Record<string, string> }): ContextOptions {
+  const resolved = Intl.DateTimeFormat().resolvedOptions();
+  const headers = Object.fromEntries(
+    Object.entries(browser.headers ?? {}).filter(([name, value]) => name.trim() && typeof value === 'string')
+  );
+  return {
+    userAgent: browser.userAgent?.trim() || DEFAULT_USER_AGENT,
+    extraHTTPHeaders: Object.keys(headers).length > 0 ? headers : undefined,
+    viewport: { width: 1920, height: 1080 },
+    locale: resolved.locale || 'en-US',
+    timezoneId: resolved.timeZone || 'UTC',
+  };
+}
+
+/** Anything scripts can be registered on before page load (a Playwright BrowserContext or Page). */
+export interface InitScriptTarget {
+  addInitScript(script: () => void): Promise<void>;
+}
+
+/**
+ * Runs in the page before any site script. Must stay self-contained:
+ * Playwright serializes the function source, so it can't close over imports.
+ */
+export function stealthInitScript(): void {
+  // Remove webdriver flag
+  Object.defineProperty(navigator, 'webdriver', { get: () => undefined });
+
+  // Mock plugins (real browsers have these)
+  Object.defineProperty(navigator, 'plugins', {
+    get: () => [
+      { name: 'Chrome PDF Plugin', filename: 'internal-pdf-viewer' },
+      { name: 'Chrome PDF Viewer', filename: 'mhjfbmdgcfjbbpaeojofohoefgiehjai' },
+      { name: 'Native Client', filename: 'internal-nacl-plugin' },
+    ],
+  });
+
+  // Mock languages
+  Object.defineProperty(navigator, 'languages', {
+    get: () => navigator.language ? [navigator.language, 'en'] : ['en'],
+  });
+
+  // Hide automation-related Chrome properties
+  const originalQuery = window.navigator.permissions.query;
+  window.navigator.permissions.query = (parameters: PermissionDescriptor) => {
+    if (parameters.name === 'notifications') {
+      return Promise.resolve({ state: 'prompt', onchange: null } as PermissionStatus);
+    }
+    return originalQuery(parameters);
+  };
+
+  // Mask Chrome property
+  (window as unknown as { chrome: unknown }).chrome = { runtime: {} };
+}
+
+/**
+ * Register the stealth script on a context so every page opened from it is
+ * masked. Controlled by browser.stealth (on unless set to false).
+ */
+export async function applyStealth(target: InitScriptTarget, enabled = true): Promise<boolean> {
+  if (!enabled) return false;
+  await target.addInitScript(stealthInitScript);
+  return true;
+}
@@ -1,6 +1,7 @@
 import { BaseScraper, type SubmissionOptions, type SubmissionResult } from './base';
 import type { JobData, CustomQuestion, Platform } from '../types';
 import { FormFiller } from '../core/form-filler';
+import { isRemoteJob } from '../utils/location';
 
 export class TeamtailorScraper extends BaseScraper {
   platform: Platform = 'teamtailor';
@@ -33,6 +34,10 @@ export class TeamtailorScraper extends BaseScraper {
       // Fill form
       await this.fillTeamtailorForm(options, errors);
 
+      if (options.preview) {
+        return this.previewSubmission(errors);
+      }
+
       // Submit
       const submitted = await this.clickSubmit();
       if (!submitted) {
@@ -186,9 +191,7 @@ export class TeamtailorScraper extends BaseScraper {
     const description = descriptionParts.join('\n\n');
 
     // Check remote
-    const remote =
-      location.toLowerCase().includes('remote') ||
-      jobType.toLowerCase().includes('remote');
+    const remote = isRemoteJob({ location, job_type: jobType });
 
     // Extract form fields
     const formFields = await this.extractFormFields();
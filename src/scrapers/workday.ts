@@ -1,6 +1,7 @@
 import { BaseScraper, type SubmissionOptions, type SubmissionResult } from './base';
 import type { JobData, CustomQuestion, Platform } from '../types';
 import { FormFiller } from '../core/form-filler';
+import { splitName } from '../utils/name';
 
 export class WorkdayScraper extends BaseScraper {
   platform: Platform = 'workday';
@@ -120,6 +121,10 @@ export class WorkdayScraper extends BaseScraper {
       if (submitButton) {
         const isEnabled = await submitButton.isEnabled();
         if (isEnabled) {
+          if (options.preview) {
+            return this.previewSubmission(errors);
+          }
+
           await this.humanDelay(true);
           await submitButton.click();
           return this.waitForWorkdayConfirmation();
@@ -149,8 +154,9 @@ export class WorkdayScraper extends BaseScraper {
     const { profile } = options;
 
     // Name fields
-    await this.fillWorkdayInput('[data-automation-id="legalNameSection_firstName"]', profile.name.split(' ')[0]);
-    await this.fillWorkdayInput('[data-automation-id="legalNameSection_lastName"]', profile.name.split(' ').slice(1).join(' '));
+    const { first, last } = splitName(profile.name);
+    await this.fillWorkdayInput('[data-automation-id="legalNameSection_firstName"]', first);
+    await this.fillWorkdayInput('[data-automation-id="legalNameSection_lastName"]', last);
     await this.fillWorkdayInput('[data-automation-id="email"]', profile.email);
 
     if (profile.phone) {
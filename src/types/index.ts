@@ -31,7 +31,7 @@ export const SUPPORTED_PLATFORMS: Record<Platform, RegExp> = {
 // ============ Profile Schemas ============
 export const PreferencesSchema = z.object({
   remote_only: z.boolean().default(false),
-  min_salary: z.number().optional(),
+  min_salary: z.number().nonnegative().optional(),
   preferred_locations: z.array(z.string()).default([]),
   excluded_companies: z.array(z.string()).default([]),
   job_types: z.array(z.string()).default(['full-time']),
@@ -45,6 +45,8 @@ export const ExperienceSchema = z.object({
   end_date: z.string().optional(),
   description: z.string().optional(),
   highlights: z.array(z.string()).default([]),
+  /** Skills used in this role; recent ones count toward job fit */
+  skills: z.array(z.string()).optional(),
 });
 
 export const EducationSchema = z.object({
@@ -83,6 +85,10 @@ export type Profile = z.infer<typeof ProfileSchema>;
 // ============ Application Types ============
 export type ApplicationStatus = 'pending' | 'submitted' | 'failed';
 
+/** How an application was sent: by autoply in the browser, or by the user */
+export const APPLY_METHODS = ['auto', 'manual', 'referral', 'email'] as const;
+export type ApplyMethod = (typeof APPLY_METHODS)[number];
+
 export interface Application {
   id?: number;
   profile_id: number;
@@ -91,10 +97,15 @@ export interface Application {
   company: string;
   job_title: string;
   status: ApplicationStatus;
+  apply_method?: ApplyMethod;
   generated_resume?: string;
   generated_cover_letter?: string;
   form_data?: Record<string, unknown>;
   error_message?: string;
+  /** Free-form notes; "history note --append" adds timestamped lines at the top */
+  notes?: string;
+  /** Resume the documents were tailored from: "profile" or a --base-resume path (unset on older records) */
+  base_resume_source?: string;
   applied_at?: string;
   created_at?: string;
 }
@@ -140,8 +151,30 @@ export type AIProviderType = 'openai' | 'anthropic' | 'google' | 'ollama' | 'lms
 export interface AIConfig {
   provider: AIProviderType;
   model: string;
+  /** Model for resume tailoring; falls back to model */
+  resumeModel?: string;
+  /** Model for cover letters; falls back to model */
+  coverLetterModel?: string;
   baseUrl?: string;
   temperature?: number;
+  /** Persona or writing-style instructions sent with every request, ahead of the task's own instructions */
+  systemPrompt?: string;
+  /** Log prompts and raw responses to ~/.autoply/logs/ai.log */
+  debug?: boolean;
+  /** Providers to try in order when the primary one fails (quota, outage, bad key) */
+  fallbacks?: AIFallbackConfig[];
+  /** Per-request timeout in ms (default 120000) */
+  timeout?: number;
+  /** Proxy URL for AI requests; HTTPS_PROXY / HTTP_PROXY are used when unset */
+  proxy?: string;
+  /** Accept self-signed certificates, e.g. from a local gateway */
+  insecureSkipVerify?: boolean;
+}
+
+export interface AIFallbackConfig {
+  provider: AIProviderType;
+  model?: string;
+  baseUrl?: string;
 }
 
 export interface AIProvider {
@@ -162,6 +195,20 @@ export interface AppConfig {
     headless: boolean;
     timeout: number;
     storageState?: string;
+    /** How long scraped job pages are reused before scraping again (default 60) */
+    cacheTtlMinutes?: number;
+    /** Mask automation signals (navigator.webdriver etc.); on unless false */
+    stealth?: boolean;
+    /** User agent for every page; defaults to a current desktop Chrome */
+    userAgent?: string;
+    /** Extra request headers, e.g. {"Accept-Language": "en-GB"} */
+    headers?: Record<string, string>;
+    /** Refuse to scrape job pages that robots.txt disallows (off by default) */
+    respectRobotsTxt?: boolean;
+    /** Most scrolls while loading a job page; stops early once nothing new loads (default 4) */
+    maxScrolls?: number;
+    /** Most browsers autoply keeps open at once (default 2) */
+    maxBrowsers?: number;
   };
   application: {
     autoSubmit: boolean;
@@ -169,14 +216,35 @@ export interface AppConfig {
     retryAttempts: number;
     /** Delay in seconds between applications in bulk mode (0 = no delay) */
     rateLimitDelay: number;
+    /** Stop applying once this many applications were submitted in the last hour / 24 hours (unset = no cap) */
+    maxPerHour?: number;
+    maxPerDay?: number;
+    /** Fit score a job needs to count as a strong match; apply skips jobs below it when set */
     minFitScore?: number;
+    /** Lower edge of the "maybe" band below minFitScore (default 50) */
+    maybeFitScore?: number;
+    /** Fit score assumed when the model's evaluation can't be read (default 50) */
+    neutralFitScore?: number;
+    /** Seconds to count down after confirming a submission, to allow Ctrl+C (default 5, 0 = none) */
+    submitCountdown?: number;
     /** When true, prompt user for fields that can't be auto-filled or AI-answered */
     interactivePrompts: boolean;
+    /** Keep generated resumes and cover letters so "generate get" can print them again; on unless false */
+    saveGeneratedDocuments?: boolean;
+    /** Where "generate" writes documents when no path is given (default ~/.autoply/output) */
+    outputDir?: string;
   };
   /** Cached answers for form fields the user has previously provided manually */
   cachedAnswers?: Record<string, string>;
+  /**
+   * Regex overrides for form field detection, keyed by "*" (all platforms)
+   * or a platform name, then by field (e.g. firstName, email, resume).
+   */
+  fieldPatterns?: FieldPatternOverrides;
 }
 
+export type FieldPatternOverrides = Partial<Record<'*' | Platform, Record<string, string>>>;
+
 export const DEFAULT_CONFIG: AppConfig = {
   ai: {
     provider: 'ollama',
@@ -201,7 +269,8 @@ export const DEFAULT_CONFIG: AppConfig = {
 export interface QueueItem {
   id: string;
   url: string;
-  status: 'pending' | 'processing' | 'completed' | 'failed';
+  /** skipped: turned down by the user's own filters, so --retry-failed leaves it alone */
+  status: 'pending' | 'processing' | 'completed' | 'failed' | 'skipped';
   error?: string;
   result?: Application;
 }
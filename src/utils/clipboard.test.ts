@@ -0,0 +1,53 @@
+import { describe, expect, test } from 'bun:test';
+import { clipboardCommandsFor, copyToClipboard, ClipboardUnavailableError } from './clipboard';
+
+describe('clipboardCommandsFor', () => {
+  test('uses pbcopy on macOS', () => {
+    expect(clipboardCommandsFor('darwin')).toEqual([{ command: 'pbcopy', args: [] }]);
+  });
+
+  test('uses clip on Windows', () => {
+    expect(clipboardCommandsFor('win32')).toEqual([{ command: 'clip', args: [] }]);
+  });
+
+  test('tries Wayland, then X11 tools elsewhere', () => {
+    expect(clipboardCommandsFor('linux').map((c) => c.command)).toEqual(['wl-copy', 'xclip', 'xsel']);
+    expect(clipboardCommandsFor('linux')[1].args).toEqual(['-selection', 'clipboard']);
+  });
+});
+
+describe('copyToClipboard', () => {
+  test('pipes the text to the platform tool', async () => {
+    const calls: Array<[string, string]> = [];
+    const used = await copyToClipboard('Dear Acme,', async (command, _args, input) => {
+      calls.push([command, input]);
+    }, 'darwin');
+
+    expect(used).toBe('pbcopy');
+    expect(calls).toEqual([['pbcopy', 'Dear Acme,']]);
+  });
+
+  test('falls back to the next tool when one is missing', async () => {
+    const used = await copyToClipboard('text', async (command) => {
+      if (command === 'wl-copy') throw new Error('spawn wl-copy ENOENT');
+    }, 'linux');
+
+    expect(used).toBe('xclip');
+  });
+
+  test('reports when no tool is available', async () => {
+    const run = async () => {
+      throw new Error('ENOENT');
+    };
+
+    await expect(copyToClipboard('text', run, 'linux')).rejects.toBeInstanceOf(ClipboardUnavailableError);
+  });
+
+  test('names every tool it tried', async () => {
+    const run = async () => {
+      throw new Error('exited with code 1');
+    };
+
+    await expect(copyToClipboard('text', run, 'linux')).rejects.toThrow('tried wl-copy, xclip, xsel');
+  });
+});
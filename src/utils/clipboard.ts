@@ -0,0 +1,64 @@
+import { spawn } from 'child_process';
+
+export interface ClipboardCommand {
+  command: string;
+  args: string[];
+}
+
+/** Runs a command with text on stdin; rejects if it can't be started or exits non-zero. */
+export type ClipboardRunner = (command: string, args: string[], input: string) => Promise<void>;
+
+export class ClipboardUnavailableError extends Error {
+  constructor(tried: string[]) {
+    super(`No clipboard tool found (tried ${tried.join(', ')})`);
+    this.name = 'ClipboardUnavailableError';
+  }
+}
+
+/** Clipboard tools to try, in order, on each platform. */
+export function clipboardCommandsFor(platform: NodeJS.Platform = process.platform): ClipboardCommand[] {
+  switch (platform) {
+    case 'darwin':
+      return [{ command: 'pbcopy', args: [] }];
+    case 'win32':
+      return [{ command: 'clip', args: [] }];
+    default:
+      return [
+        { command: 'wl-copy', args: [] },
+        { command: 'xclip', args: ['-selection', 'clipboard'] },
+        { command: 'xsel', args: ['--clipboard', '--input'] },
+      ];
+  }
+}
+
+const spawnWithInput: ClipboardRunner = (command, args, input) =>
+  new Promise((resolve, reject) => {
+    const child = spawn(command, args, { stdio: ['pipe', 'ignore', 'ignore'] });
+    child.once('error', reject);
+    child.once('close', (code) => {
+      if (code === 0) resolve();
+      else reject(new Error(`${command} exited with code ${code}`));
+    });
+    child.stdin.end(input);
+  });
+
+/**
+ * Copy text to the system clipboard. Returns the tool that was used, or
+ * throws ClipboardUnavailableError when none of them work.
+ */
+export async function copyToClipboard(
+  text: string,
+  run: ClipboardRunner = spawnWithInput,
+  platform: NodeJS.Platform = process.platform
+): Promise<string> {
+  const candidates = clipboardCommandsFor(platform);
+  for (const { command, args } of candidates) {
+    try {
+      await run(command, args, text);
+      return command;
+    } catch {
+      // Not installed or no display; try the next tool
+    }
+  }
+  throw new ClipboardUnavailableError(candidates.map((c) => c.command));
+}
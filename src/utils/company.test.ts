@@ -0,0 +1,47 @@
+import { describe, expect, test } from 'bun:test';
+import { addToBlocklist, findBlockedCompany, normalizeCompanyName, removeFromBlocklist } from './company';
+
+describe('normalizeCompanyName', () => {
+  test('ignores case, punctuation and legal suffixes', () => {
+    expect(normalizeCompanyName('Acme, Inc.')).toBe('acme');
+    expect(normalizeCompanyName('ACME')).toBe('acme');
+    expect(normalizeCompanyName('Globex Corporation Ltd')).toBe('globex');
+    expect(normalizeCompanyName('Procter & Gamble Co.')).toBe('procter and gamble');
+  });
+
+  test('keeps a name that is only a suffix word', () => {
+    expect(normalizeCompanyName('Company')).toBe('company');
+  });
+});
+
+describe('findBlockedCompany', () => {
+  const blocklist = ['Initech', 'Meta', 'Umbrella Corp'];
+
+  test('matches case-insensitively and on partial names', () => {
+    expect(findBlockedCompany('INITECH', blocklist)).toBe('Initech');
+    expect(findBlockedCompany('Initech Labs GmbH', blocklist)).toBe('Initech');
+    expect(findBlockedCompany('Umbrella Corporation', blocklist)).toBe('Umbrella Corp');
+  });
+
+  test('does not match inside other words', () => {
+    expect(findBlockedCompany('Metabase', blocklist)).toBeUndefined();
+    expect(findBlockedCompany('Acme', blocklist)).toBeUndefined();
+  });
+
+  test('ignores empty names and entries', () => {
+    expect(findBlockedCompany('', blocklist)).toBeUndefined();
+    expect(findBlockedCompany('Acme', ['  '])).toBeUndefined();
+  });
+});
+
+describe('blocklist management', () => {
+  test('add skips equivalent entries', () => {
+    const list = addToBlocklist(['Acme'], 'acme inc');
+    expect(list).toEqual(['Acme']);
+    expect(addToBlocklist(list, ' Globex ')).toEqual(['Acme', 'Globex']);
+  });
+
+  test('remove drops every equivalent entry', () => {
+    expect(removeFromBlocklist(['Acme', 'ACME Inc.', 'Globex'], 'acme')).toEqual(['Globex']);
+  });
+});
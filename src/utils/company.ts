@@ -0,0 +1,51 @@
+const LEGAL_SUFFIXES = new Set([
+  'inc', 'incorporated', 'llc', 'ltd', 'limited', 'corp', 'corporation', 'co', 'company',
+  'gmbh', 'ag', 'sa', 'sas', 'bv', 'plc', 'pty', 'oy', 'ab', 'srl',
+]);
+
+/**
+ * Lower-case, strip punctuation and trailing legal suffixes so
+ * "Acme, Inc." and "ACME" compare equal.
+ */
+export function normalizeCompanyName(name: string): string {
+  const words = name
+    .toLowerCase()
+    .replace(/&/g, ' and ')
+    .replace(/[^\p{L}\p{N}]+/gu, ' ')
+    .trim()
+    .split(/\s+/)
+    .filter(Boolean);
+  while (words.length > 1 && LEGAL_SUFFIXES.has(words[words.length - 1])) {
+    words.pop();
+  }
+  return words.join(' ');
+}
+
+/**
+ * The blocklist entry that matches a company, if any. Entries match whole
+ * words anywhere in the name, so "Initech" also blocks "Initech Labs" but
+ * "Meta" doesn't block "Metabase".
+ */
+export function findBlockedCompany(company: string, blocklist: string[]): string | undefined {
+  const target = ` ${normalizeCompanyName(company)} `;
+  if (target.trim() === '') return undefined;
+  return blocklist.find((entry) => {
+    const normalized = normalizeCompanyName(entry);
+    return normalized !== '' && target.includes(` ${normalized} `);
+  });
+}
+
+/** Add a company unless an equivalent entry is already there. */
+export function addToBlocklist(blocklist: string[], company: string): string[] {
+  const normalized = normalizeCompanyName(company);
+  if (!normalized || blocklist.some((entry) => normalizeCompanyName(entry) === normalized)) {
+    return blocklist;
+  }
+  return [...blocklist, company.trim()];
+}
+
+/** Remove every entry equivalent to the given company. */
+export function removeFromBlocklist(blocklist: string[], company: string): string[] {
+  const normalized = normalizeCompanyName(company);
+  return blocklist.filter((entry) => normalizeCompanyName(entry) !== normalized);
+}
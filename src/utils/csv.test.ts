@@ -0,0 +1,38 @@
+import { describe, expect, test } from 'bun:test';
+import { parseCsv, parseCsvRecords, escapeCsvField } from './csv';
+
+describe('parseCsv', () => {
+  test('handles quoted fields with commas, quotes and newlines', () => {
+    expect(parseCsv('a,"b, c","say ""hi""","multi\nline"\n')).toEqual([
+      ['a', 'b, c', 'say "hi"', 'multi\nline'],
+    ]);
+  });
+
+  test('handles CRLF line endings and skips blank lines', () => {
+    expect(parseCsv('a,b\r\n\r\nc,d\r\n')).toEqual([
+      ['a', 'b'],
+      ['c', 'd'],
+    ]);
+  });
+});
+
+describe('parseCsvRecords', () => {
+  test('keys rows by lower-cased header and tolerates a BOM', () => {
+    expect(parseCsvRecords('\uFEFFTitle,URL\nEngineer, https://example.com/1 \n')).toEqual([
+      { title: 'Engineer', url: 'https://example.com/1' },
+    ]);
+  });
+
+  test('fills missing trailing cells with empty strings', () => {
+    expect(parseCsvRecords('url,company\nhttps://example.com/1\n')).toEqual([
+      { url: 'https://example.com/1', company: '' },
+    ]);
+  });
+});
+
+describe('escapeCsvField', () => {
+  test('quotes only when needed', () => {
+    expect(escapeCsvField('plain')).toBe('plain');
+    expect(escapeCsvField('a, "b"')).toBe('"a, ""b"""');
+  });
+});
@@ -0,0 +1,69 @@
+/**
+ * Minimal RFC 4180 CSV parsing: quoted fields, escaped quotes ("") and
+ * newlines inside quotes. Enough for spreadsheet exports.
+ */
+export function parseCsv(content: string): string[][] {
+  const rows: string[][] = [];
+  let row: string[] = [];
+  let field = '';
+  let inQuotes = false;
+
+  for (let i = 0; i < content.length; i++) {
+    const char = content[i];
+
+    if (inQuotes) {
+      if (char === '"' && content[i + 1] === '"') {
+        field += '"';
+        i++;
+      } else if (char === '"') {
+        inQuotes = false;
+      } else {
+        field += char;
+      }
+      continue;
+    }
+
+    if (char === '"') {
+      inQuotes = true;
+    } else if (char === ',') {
+      row.push(field);
+      field = '';
+    } else if (char === '\n' || char === '\r') {
+      if (char === '\r' && content[i + 1] === '\n') i++;
+      row.push(field);
+      rows.push(row);
+      row = [];
+      field = '';
+    } else {
+      field += char;
+    }
+  }
+
+  if (field || row.length > 0) {
+    row.push(field);
+    rows.push(row);
+  }
+
+  return rows.filter((r) => r.some((cell) => cell.trim()));
+}
+
+/**
+ * Parse CSV with a header row into objects keyed by lower-cased column name.
+ */
+export function parseCsvRecords(content: string): Record<string, string>[] {
+  const [header, ...rows] = parseCsv(content.replace(/^\uFEFF/, ''));
+  if (!header) return [];
+
+  const columns = header.map((name) => name.trim().toLowerCase());
+  return rows.map((cells) => {
+    const record: Record<string, string> = {};
+    columns.forEach((column, i) => {
+      record[column] = (cells[i] ?? '').trim();
+    });
+    return record;
+  });
+}
+
+export function escapeCsvField(value: string): string {
+  return /[",\r\n]/.test(value) ? `"${value.replace(/"/g, '""')}"` : value;
+}
@@ -1,5 +1,7 @@
 import { describe, expect, test } from 'bun:test';
+import { join } from 'path';
 import {
+  extractTextFromFile,
   isSupportedExtension,
   validateDocumentPath,
   getSupportedFormatsDescription,
@@ -17,6 +19,10 @@ describe('document-extractor', () => {
       expect(SUPPORTED_EXTENSIONS).toContain('.markdown');
     });
 
+    test('includes Word documents', () => {
+      expect(SUPPORTED_EXTENSIONS).toContain('.docx');
+    });
+
     test('includes plain text', () => {
       expect(SUPPORTED_EXTENSIONS).toContain('.txt');
     });
@@ -37,8 +43,11 @@ describe('document-extractor', () => {
       expect(isSupportedExtension('resume.txt')).toBe(true);
     });
 
+    test('returns true for Word documents', () => {
+      expect(isSupportedExtension('resume.docx')).toBe(true);
+    });
+
     test('returns false for unsupported extensions', () => {
-      expect(isSupportedExtension('resume.docx')).toBe(false);
       expect(isSupportedExtension('resume.doc')).toBe(false);
       expect(isSupportedExtension('resume.rtf')).toBe(false);
       expect(isSupportedExtension('resume.html')).toBe(false);
@@ -81,10 +90,21 @@ describe('document-extractor', () => {
       const description = getSupportedFormatsDescription();
       expect(description).toContain('PDF');
       expect(description).toContain('.pdf');
+      expect(description).toContain('.docx');
       expect(description).toContain('Markdown');
       expect(description).toContain('.md');
       expect(description).toContain('text');
       expect(description).toContain('.txt');
     });
   });
+
+  describe('extractTextFromFile', () => {
+    test('extracts text from a .docx file', async () => {
+      const result = await extractTextFromFile(join(import.meta.dir, '__fixtures__', 'resume.docx'));
+
+      expect(result.success).toBe(true);
+      expect(result.fileType).toBe('docx');
+      expect(result.content).toContain('Ada Lovelace');
+    });
+  });
 });
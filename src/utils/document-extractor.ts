@@ -1,16 +1,17 @@
 /**
  * Document text extraction utilities
- * Supports PDF, Markdown, and plain text files
+ * Supports PDF, Word (.docx), Markdown, and plain text files
  */
 
 import { existsSync } from 'fs';
 import { readFile } from 'fs/promises';
 import { extname, resolve } from 'path';
+import { extractTextFromDocxBuffer } from './docx';
 
 /**
  * Supported file extensions for document import
  */
-export const SUPPORTED_EXTENSIONS = ['.pdf', '.md', '.markdown', '.txt'] as const;
+export const SUPPORTED_EXTENSIONS = ['.pdf', '.docx', '.md', '.markdown', '.txt'] as const;
 
 export type SupportedExtension = (typeof SUPPORTED_EXTENSIONS)[number];
 
@@ -34,7 +35,7 @@ export function isSupportedExtension(filePath: string): boolean {
 }
 
 /**
- * Extract text content from a file (PDF, MD, or TXT)
+ * Extract text content from a file (PDF, DOCX, MD, or TXT)
  *
  * @param filePath - Path to the file
  * @returns Extraction result with content or error
@@ -65,6 +66,8 @@ export async function extractTextFromFile(filePath: string): Promise<ExtractionR
 
     if (ext === '.pdf') {
       content = await extractTextFromPdf(absolutePath);
+    } else if (ext === '.docx') {
+      content = extractTextFromDocxBuffer(await readFile(absolutePath));
     } else {
       // MD, TXT - read as text
       content = await readFile(absolutePath, 'utf-8');
@@ -129,5 +132,5 @@ export function validateDocumentPath(filePath: string): { valid: boolean; error?
  * Get a user-friendly description of supported formats
  */
 export function getSupportedFormatsDescription(): string {
-  return 'PDF (.pdf), Markdown (.md), or plain text (.txt)';
+  return 'PDF (.pdf), Word (.docx), Markdown (.md), or plain text (.txt)';
 }
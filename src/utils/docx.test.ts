@@ -0,0 +1,33 @@
+import { describe, expect, test } from 'bun:test';
+import { readFileSync } from 'fs';
+import { join } from 'path';
+import { documentXmlToText, extractTextFromDocxBuffer, DocxError } from './docx';
+
+const fixture = readFileSync(join(import.meta.dir, '__fixtures__', 'resume.docx'));
+
+describe('extractTextFromDocxBuffer', () => {
+  test('reads paragraphs from word/document.xml', () => {
+    expect(extractTextFromDocxBuffer(fixture)).toBe(
+      'Ada Lovelace\nSenior Engineer at Analytical Engines & Co\nSkills:\tTypeScript, Go'
+    );
+  });
+
+  test('rejects password-protected documents', () => {
+    const ole = Buffer.alloc(512);
+    Buffer.from([0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1]).copy(ole);
+
+    expect(() => extractTextFromDocxBuffer(ole)).toThrow(/password-protected/);
+  });
+
+  test('rejects files that are not zip archives', () => {
+    expect(() => extractTextFromDocxBuffer(Buffer.from('just some text, not a zip file'))).toThrow(DocxError);
+  });
+});
+
+describe('documentXmlToText', () => {
+  test('keeps breaks and decodes entities', () => {
+    const xml = '<w:p><w:r><w:t>A &lt;b&gt;</w:t><w:br/><w:t>&#233;t&#xE9;</w:t></w:r></w:p>';
+
+    expect(documentXmlToText(xml)).toBe('A <b>\nété');
+  });
+});
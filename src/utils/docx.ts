@@ -0,0 +1,117 @@
+/**
+ * Minimal DOCX text extraction.
+ * A .docx is a zip archive; the body text lives in word/document.xml.
+ */
+
+import { inflateRawSync } from 'zlib';
+
+const EOCD_SIGNATURE = 0x06054b50;
+const CENTRAL_ENTRY_SIGNATURE = 0x02014b50;
+const LOCAL_HEADER_SIGNATURE = 0x04034b50;
+// Encrypted Office files are stored in an OLE compound file, not a zip
+const OLE_SIGNATURE = Buffer.from([0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1]);
+
+const DOCUMENT_PATH = 'word/document.xml';
+
+export class DocxError extends Error {
+  constructor(message: string) {
+    super(message);
+    this.name = 'DocxError';
+  }
+}
+
+function findEndOfCentralDirectory(buffer: Buffer): number {
+  // The record is 22 bytes plus a comment of up to 64KB
+  const stop = Math.max(0, buffer.length - 22 - 0xffff);
+  for (let i = buffer.length - 22; i >= stop; i--) {
+    if (buffer.readUInt32LE(i) === EOCD_SIGNATURE) return i;
+  }
+  return -1;
+}
+
+/**
+ * Read a single file out of a zip archive, or null if it isn't there.
+ */
+export function readZipEntry(buffer: Buffer, name: string): Buffer | null {
+  const eocd = findEndOfCentralDirectory(buffer);
+  if (eocd < 0) throw new DocxError('Not a valid .docx file (zip directory not found)');
+
+  const entryCount = buffer.readUInt16LE(eocd + 10);
+  let offset = buffer.readUInt32LE(eocd + 16);
+
+  for (let i = 0; i < entryCount; i++) {
+    if (offset + 46 > buffer.length || buffer.readUInt32LE(offset) !== CENTRAL_ENTRY_SIGNATURE) {
+      throw new DocxError('Not a valid .docx file (corrupt zip directory)');
+    }
+
+    const flags = buffer.readUInt16LE(offset + 8);
+    const method = buffer.readUInt16LE(offset + 10);
+    const compressedSize = buffer.readUInt32LE(offset + 20);
+    const nameLength = buffer.readUInt16LE(offset + 28);
+    const extraLength = buffer.readUInt16LE(offset + 30);
+    const commentLength = buffer.readUInt16LE(offset + 32);
+    const localOffset = buffer.readUInt32LE(offset + 42);
+    const entryName = buffer.toString('utf-8', offset + 46, offset + 46 + nameLength);
+
+    if (entryName === name) {
+      if (flags & 0x1) throw new DocxError('The .docx file is password-protected');
+      if (buffer.readUInt32LE(localOffset) !== LOCAL_HEADER_SIGNATURE) {
+        throw new DocxError('Not a valid .docx file (corrupt zip entry)');
+      }
+
+      const dataStart = localOffset + 30
+        + buffer.readUInt16LE(localOffset + 26)
+        + buffer.readUInt16LE(localOffset + 28);
+      const data = buffer.subarray(dataStart, dataStart + compressedSize);
+
+      if (method === 0) return data;
+      if (method === 8) return inflateRawSync(data);
+      throw new DocxError(`Unsupported zip compression method: ${method}`);
+    }
+
+    offset += 46 + nameLength + extraLength + commentLength;
+  }
+
+  return null;
+}
+
+function decodeXmlEntities(text: string): string {
+  return text
+    .replace(/&#x([0-9a-f]+);/gi, (_, hex) => String.fromCodePoint(parseInt(hex, 16)))
+    .replace(/&#(\d+);/g, (_, dec) => String.fromCodePoint(parseInt(dec, 10)))
+    .replace(/&lt;/g, '<')
+    .replace(/&gt;/g, '>')
+    .replace(/&quot;/g, '"')
+    .replace(/&apos;/g, "'")
+    .replace(/&amp;/g, '&');
+}
+
+/**
+ * Turn WordprocessingML into plain text: one line per paragraph, with tabs
+ * and line breaks kept.
+ */
+export function documentXmlToText(xml: string): string {
+  const text = xml
+    .replace(/<w:tab\/>/g, '\t')
+    .replace(/<w:(br|cr)\/>/g, '\n')
+    .replace(/<\/w:p>/g, '\n')
+    .replace(/<[^>]+>/g, '');
+
+  return decodeXmlEntities(text)
+    .split('\n')
+    .map((line) => line.trimEnd())
+    .join('\n')
+    .replace(/\n{3,}/g, '\n\n')
+    .trim();
+}
+
+export function extractTextFromDocxBuffer(buffer: Buffer): string {
+  if (buffer.subarray(0, OLE_SIGNATURE.length).equals(OLE_SIGNATURE)) {
+    throw new DocxError('The .docx file is password-protected or in the old .doc format; save it as an unprotected .docx');
+  }
+
+  const documentXml = readZipEntry(buffer, DOCUMENT_PATH);
+  if (!documentXml) throw new DocxError(`Not a Word document (${DOCUMENT_PATH} is missing)`);
+
+  return documentXmlToText(documentXml.toString('utf-8'));
+}
@@ -0,0 +1,17 @@
+import { describe, expect, test } from 'bun:test';
+import { parseDuration } from './duration';
+
+describe('parseDuration', () => {
+  test('parses minutes, hours, days and weeks', () => {
+    expect(parseDuration('90m')).toBe(90 * 60_000);
+    expect(parseDuration('12h')).toBe(12 * 60 * 60_000);
+    expect(parseDuration('30d')).toBe(30 * 24 * 60 * 60_000);
+    expect(parseDuration(' 2W ')).toBe(14 * 24 * 60 * 60_000);
+  });
+
+  test('rejects values without a unit or with an unknown one', () => {
+    expect(() => parseDuration('30')).toThrow('Invalid duration');
+    expect(() => parseDuration('1y')).toThrow('Invalid duration');
+    expect(() => parseDuration('')).toThrow('Invalid duration');
+  });
+});
@@ -0,0 +1,15 @@
+const UNITS: Record<string, number> = {
+  m: 60_000,
+  h: 60 * 60_000,
+  d: 24 * 60 * 60_000,
+  w: 7 * 24 * 60 * 60_000,
+};
+
+/** Parse an age like "30d", "12h", "2w" or "90m" into milliseconds. */
+export function parseDuration(value: string): number {
+  const match = value.trim().toLowerCase().match(/^(\d+(?:\.\d+)?)\s*([mhdw])$/);
+  if (!match) {
+    throw new Error(`Invalid duration: ${value} (use e.g. 30d, 12h, 2w)`);
+  }
+  return Number(match[1]) * UNITS[match[2]];
+}
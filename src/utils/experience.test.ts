@@ -0,0 +1,108 @@
+import { describe, expect, test } from 'bun:test';
+import {
+  parseMonthIndex,
+  totalExperienceYears,
+  classifyJobSeniority,
+  seniorityFromYears,
+  seniorityPenalty,
+  addSkillToExperience,
+  recentExperienceSkills,
+} from './experience';
+import type { Experience } from '../types';
+
+const NOW = new Date(2026, 5, 15);
+
+function role(start_date: string, end_date?: string): Experience {
+  return { company: 'Acme', title: 'Engineer', start_date, end_date, highlights: [] };
+}
+
+describe('parseMonthIndex', () => {
+  test('parses common resume date formats', () => {
+    expect(parseMonthIndex('2020', NOW)).toBe(2020 * 12);
+    expect(parseMonthIndex('2020-03', NOW)).toBe(2020 * 12 + 2);
+    expect(parseMonthIndex('2020-01-01', NOW)).toBe(2020 * 12);
+    expect(parseMonthIndex('Mar 2020', NOW)).toBe(2020 * 12 + 2);
+  });
+
+  test('treats Present and missing dates as now', () => {
+    expect(parseMonthIndex('Present', NOW)).toBe(2026 * 12 + 5);
+    expect(parseMonthIndex(undefined, NOW)).toBe(2026 * 12 + 5);
+  });
+
+  test('returns undefined for garbage', () => {
+    expect(parseMonthIndex('sometime', NOW)).toBeUndefined();
+  });
+});
+
+describe('totalExperienceYears', () => {
+  test('sums separate roles', () => {
+    expect(totalExperienceYears([role('2018-01', '2020-01'), role('2021-01', '2023-01')], NOW)).toBe(4);
+  });
+
+  test('counts overlapping roles once', () => {
+    const roles = [role('2018-01', '2022-01'), role('2020-01', '2021-01'), role('2021-06', '2023-01')];
+    expect(totalExperienceYears(roles, NOW)).toBe(5);
+  });
+
+  test('runs ongoing roles up to now', () => {
+    expect(totalExperienceYears([role('2024-06')], NOW)).toBe(2);
+    expect(totalExperienceYears([role('2024-06', 'Present')], NOW)).toBe(2);
+  });
+
+  test('ignores roles with unparseable or inverted dates', () => {
+    expect(totalExperienceYears([role('unknown', '2020'), role('2022', '2021')], NOW)).toBe(0);
+  });
+});
+
+describe('classifyJobSeniority', () => {
+  test.each([
+    ['Senior Software Engineer', 'senior'],
+    ['Sr. Backend Developer', 'senior'],
+    ['Senior Staff Engineer', 'staff'],
+    ['Principal Engineer', 'principal'],
+    ['Junior Developer', 'junior'],
+    ['Software Engineering Intern', 'intern'],
+    ['Software Engineer', undefined],
+  ])('%s -> %s', (title, level) => {
+    expect(classifyJobSeniority(title)).toBe(level as ReturnType<typeof classifyJobSeniority>);
+  });
+});
+
+describe('seniorityPenalty', () => {
+  test('no penalty within one level or when the job does not say', () => {
+    expect(seniorityPenalty('mid', 'senior')).toBe(0);
+    expect(seniorityPenalty('senior', 'mid')).toBe(0);
+    expect(seniorityPenalty('junior', undefined)).toBe(0);
+  });
+
+  test('penalizes underqualified candidates more than overqualified ones', () => {
+    expect(seniorityPenalty(seniorityFromYears(1), 'senior')).toBe(20);
+    expect(seniorityPenalty(seniorityFromYears(1), 'principal')).toBe(50);
+    expect(seniorityPenalty(seniorityFromYears(10), 'junior')).toBe(10);
+  });
+});
+
+describe('addSkillToExperience', () => {
+  test('adds a new skill and ignores case-insensitive duplicates', () => {
+    const tagged = addSkillToExperience(addSkillToExperience(role('2020'), 'Go'), ' go ');
+    expect(tagged.skills).toEqual(['Go']);
+    expect(addSkillToExperience(tagged, 'Kubernetes').skills).toEqual(['Go', 'Kubernetes']);
+  });
+});
+
+describe('recentExperienceSkills', () => {
+  test('collects skills from current and recently ended roles, newest first', () => {
+    const roles: Experience[] = [
+      { ...role('2015', '2019'), skills: ['Perl'] },
+      { ...role('2020', '2024-01'), skills: ['Python', 'Go'] },
+      { ...role('2024-02'), skills: ['go', 'Kubernetes'] },
+    ];
+
+    expect(recentExperienceSkills(roles, NOW)).toEqual(['go', 'Kubernetes', 'Python']);
+  });
+
+  test('is empty when no roles are tagged', () => {
+    expect(recentExperienceSkills([role('2024')], NOW)).toEqual([]);
+  });
+});
+
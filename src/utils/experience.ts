@@ -0,0 +1,141 @@
+import type { Experience } from '../types';
+
+export const SENIORITY_LEVELS = ['intern', 'junior', 'mid', 'senior', 'staff', 'principal'] as const;
+export type Seniority = (typeof SENIORITY_LEVELS)[number];
+
+const ONGOING = /^(present|current|now|ongoing|today)$/i;
+const MONTHS = ['jan', 'feb', 'mar', 'apr', 'may', 'jun', 'jul', 'aug', 'sep', 'oct', 'nov', 'dec'];
+
+/**
+ * Parse a resume date ("2020", "2020-03", "2020-03-15", "Mar 2020",
+ * "Present") into a month index. Parsed by hand so "2020-01-01" doesn't
+ * drift into December in timezones behind UTC.
+ */
+export function parseMonthIndex(value: string | undefined, now: Date = new Date()): number | undefined {
+  const text = value?.trim();
+  if (!text || ONGOING.test(text)) {
+    return now.getFullYear() * 12 + now.getMonth();
+  }
+
+  const iso = text.match(/^(\d{4})(?:[-/.](\d{1,2}))?(?:[-/.]\d{1,2})?$/);
+  if (iso) {
+    const month = iso[2] ? Number(iso[2]) - 1 : 0;
+    return Number(iso[1]) * 12 + Math.min(11, Math.max(0, month));
+  }
+
+  const named = text.match(/^([a-z]{3,9})\.?,?\s+(\d{4})$/i);
+  if (named) {
+    const month = MONTHS.indexOf(named[1].slice(0, 3).toLowerCase());
+    if (month !== -1) return Number(named[2]) * 12 + month;
+  }
+
+  const parsed = new Date(text);
+  if (Number.isNaN(parsed.getTime())) return undefined;
+  return parsed.getFullYear() * 12 + parsed.getMonth();
+}
+
+/**
+ * Total years worked, counting overlapping roles (side jobs, concurrent
+ * contracts) only once. Roles with unparseable dates are ignored.
+ */
+export function totalExperienceYears(experience: Experience[], now: Date = new Date()): number {
+  const ranges: Array<[number, number]> = [];
+  for (const exp of experience) {
+    const start = parseMonthIndex(exp.start_date, now);
+    const end = exp.end_date ? parseMonthIndex(exp.end_date, now) : parseMonthIndex(undefined, now);
+    if (start === undefined || end === undefined || end <= start) continue;
+    ranges.push([start, end]);
+  }
+
+  ranges.sort((a, b) => a[0] - b[0]);
+
+  let months = 0;
+  let current: [number, number] | undefined;
+  for (const range of ranges) {
+    if (current && range[0] <= current[1]) {
+      current[1] = Math.max(current[1], range[1]);
+    } else {
+      if (current) months += current[1] - current[0];
+      current = [...range];
+    }
+  }
+  if (current) months += current[1] - current[0];
+
+  return Math.round((months / 12) * 10) / 10;
+}
+
+const TITLE_SENIORITY: Array<[RegExp, Seniority]> = [
+  [/\b(intern|internship|co-?op)\b/i, 'intern'],
+  [/\b(principal|distinguished|fellow)\b/i, 'principal'],
+  [/\b(staff|lead)\b/i, 'staff'],
+  [/\b(senior|sr\.?)\s/i, 'senior'],
+  [/\b(junior|jr\.?|entry[\s-]level|graduate|new grad)\b/i, 'junior'],
+  [/\b(mid[\s-]level|intermediate)\b/i, 'mid'],
+];
+
+/**
+ * Seniority implied by a job title, or undefined when the title doesn't say.
+ */
+export function classifyJobSeniority(title: string): Seniority | undefined {
+  for (const [pattern, level] of TITLE_SENIORITY) {
+    if (pattern.test(`${title} `)) return level;
+  }
+  return undefined;
+}
+
+export function seniorityFromYears(years: number): Seniority {
+  if (years < 2) return 'junior';
+  if (years < 5) return 'mid';
+  if (years < 8) return 'senior';
+  if (years < 12) return 'staff';
+  return 'principal';
+}
+
+/**
+ * Points to take off a fit score when the role's seniority is far from the
+ * candidate's tenure. One level either way is normal career movement and
+ * costs nothing; being underqualified hurts more than overqualified.
+ */
+export function seniorityPenalty(candidate: Seniority, job: Seniority | undefined): number {
+  if (!job) return 0;
+
+  const gap = SENIORITY_LEVELS.indexOf(job) - SENIORITY_LEVELS.indexOf(candidate);
+  if (gap >= 2) return Math.min(50, 20 * (gap - 1));
+  if (gap <= -3) return Math.min(30, 10 * (-gap - 2));
+  return 0;
+}
+
+/** Add a skill to a role unless it's already tagged (case-insensitive). */
+export function addSkillToExperience(experience: Experience, skill: string): Experience {
+  const trimmed = skill.trim();
+  const skills = experience.skills ?? [];
+  if (!trimmed || skills.some((existing) => existing.toLowerCase() === trimmed.toLowerCase())) {
+    return experience;
+  }
+  return { ...experience, skills: [...skills, trimmed] };
+}
+
+/**
+ * Skills tagged on roles that are ongoing or ended within the last
+ * `withinYears`, most recent role first, without duplicates.
+ */
+export function recentExperienceSkills(experience: Experience[], now: Date = new Date(), withinYears = 3): string[] {
+  const cutoff = now.getFullYear() * 12 + now.getMonth() - withinYears * 12;
+  const recent = experience
+    .map((exp) => ({ exp, end: parseMonthIndex(exp.end_date, now) }))
+    .filter(({ exp, end }) => (exp.skills?.length ?? 0) > 0 && end !== undefined && end >= cutoff)
+    .sort((a, b) => b.end! - a.end!);
+
+  const seen = new Set<string>();
+  const skills: string[] = [];
+  for (const { exp } of recent) {
+    for (const skill of exp.skills!) {
+      const key = skill.trim().toLowerCase();
+      if (!key || seen.has(key)) continue;
+      seen.add(key);
+      skills.push(skill.trim());
+    }
+  }
+  return skills;
+}
+
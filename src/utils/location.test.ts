@@ -0,0 +1,76 @@
+import { describe, expect, test } from 'bun:test';
+import { normalizeLocation, formatLocation, isRemoteJob, matchLocation } from './location';
+
+describe('normalizeLocation', () => {
+  const cases: Array<[string, ReturnType<typeof normalizeLocation>]> = [
+    ['San Francisco, CA (Hybrid)', { city: 'San Francisco', region: 'CA', country: 'United States', remote: false, hybrid: true }],
+    ['Remote - US', { country: 'United States', remote: true, hybrid: false }],
+    ['Remote (USA only)', { country: 'United States', remote: true, hybrid: false }],
+    ['New York, NY, United States', { city: 'New York', region: 'NY', country: 'United States', remote: false, hybrid: false }],
+    ['Toronto, Ontario, Canada', { city: 'Toronto', region: 'Ontario', country: 'Canada', remote: false, hybrid: false }],
+    ['London, UK', { city: 'London', country: 'United Kingdom', remote: false, hybrid: false }],
+    ['Winston-Salem, NC', { city: 'Winston-Salem', region: 'NC', country: 'United States', remote: false, hybrid: false }],
+    ['Berlin | On-site', { city: 'Berlin', remote: false, hybrid: false }],
+    ['Remote-first', { remote: true, hybrid: false }],
+    ['', { remote: false, hybrid: false }],
+  ];
+
+  for (const [raw, expected] of cases) {
+    test(`parses ${JSON.stringify(raw)}`, () => {
+      expect(normalizeLocation(raw)).toEqual(expected);
+    });
+  }
+
+  test('does not treat "remote" in a city-less hybrid string as remote', () => {
+    expect(normalizeLocation('Hybrid / Remote, Austin, TX').remote).toBe(false);
+  });
+});
+
+describe('formatLocation', () => {
+  test('joins parts and appends the workplace mode', () => {
+    expect(formatLocation(normalizeLocation('San Francisco, CA (Hybrid)'))).toBe(
+      'San Francisco, CA, United States (Hybrid)'
+    );
+    expect(formatLocation(normalizeLocation('Remote'))).toBe('Remote');
+  });
+});
+
+describe('isRemoteJob', () => {
+  test('prefers an explicit remote flag', () => {
+    expect(isRemoteJob({ location: 'Remote', remote: false })).toBe(false);
+  });
+
+  test('falls back to location and job type text', () => {
+    expect(isRemoteJob({ location: 'Anywhere' })).toBe(true);
+    expect(isRemoteJob({ location: 'Austin, TX', job_type: 'Remote' })).toBe(true);
+    expect(isRemoteJob({ location: 'Austin, TX (Hybrid)' })).toBe(false);
+  });
+});
+
+describe('matchLocation', () => {
+  const cases: Array<[string, { location?: string; job_type?: string; remote?: boolean }, boolean]> = [
+    ['a remote job', { location: 'Remote - US' }, true],
+    ['a job flagged remote by the scraper', { location: 'Austin, TX', remote: true }, true],
+    ['a hybrid job', { location: 'San Francisco, CA (Hybrid)' }, false],
+    ['an on-site job', { location: 'Berlin' }, false],
+    ['a job marked not remote despite the text', { location: 'Remote', remote: false }, false],
+    ['a job with no location', {}, true],
+  ];
+
+  for (const [name, job, ok] of cases) {
+    test(`remoteOnly ${ok ? 'accepts' : 'rejects'} ${name}`, () => {
+      expect(matchLocation(job, { remoteOnly: true }).ok).toBe(ok);
+    });
+  }
+
+  test('accepts any location without remoteOnly', () => {
+    expect(matchLocation({ location: 'Berlin' }, {})).toEqual({ ok: true, where: 'Berlin' });
+  });
+
+  test('describes where the job is', () => {
+    expect(matchLocation({ location: 'Austin, TX', remote: true }, {}).where).toBe('Austin, TX, United States (Remote)');
+    expect(matchLocation({ location: 'Austin, TX (Hybrid)' }, { remoteOnly: true }).where).toBe(
+      'Austin, TX, United States (Hybrid)'
+    );
+  });
+});
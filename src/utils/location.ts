@@ -0,0 +1,136 @@
+export interface NormalizedLocation {
+  city?: string;
+  region?: string;
+  country?: string;
+  remote: boolean;
+  hybrid: boolean;
+}
+
+const US_STATES = new Set([
+  'AL', 'AK', 'AZ', 'AR', 'CA', 'CO', 'CT', 'DE', 'DC', 'FL', 'GA', 'HI', 'ID', 'IL', 'IN', 'IA',
+  'KS', 'KY', 'LA', 'ME', 'MD', 'MA', 'MI', 'MN', 'MS', 'MO', 'MT', 'NE', 'NV', 'NH', 'NJ', 'NM',
+  'NY', 'NC', 'ND', 'OH', 'OK', 'OR', 'PA', 'RI', 'SC', 'SD', 'TN', 'TX', 'UT', 'VT', 'VA', 'WA',
+  'WV', 'WI', 'WY',
+]);
+
+const COUNTRY_ALIASES: Record<string, string> = {
+  us: 'United States',
+  usa: 'United States',
+  'u.s.': 'United States',
+  'united states': 'United States',
+  'united states of america': 'United States',
+  uk: 'United Kingdom',
+  'united kingdom': 'United Kingdom',
+  'great britain': 'United Kingdom',
+  canada: 'Canada',
+  germany: 'Germany',
+  france: 'France',
+  netherlands: 'Netherlands',
+  spain: 'Spain',
+  ireland: 'Ireland',
+  india: 'India',
+  nigeria: 'Nigeria',
+  australia: 'Australia',
+  brazil: 'Brazil',
+  mexico: 'Mexico',
+  singapore: 'Singapore',
+};
+
+const REMOTE_PATTERN = /\b(remote|work from home|wfh|anywhere|distributed)\b/i;
+const HYBRID_PATTERN = /\bhybrid\b/i;
+const WORKPLACE_PATTERN = /\b(remote|hybrid|on-?site|in[- ]office|work from home|wfh|anywhere|distributed)\b/gi;
+// Qualifiers that trail a workplace marker, e.g. "Remote-first", "USA only"
+const FILLER_PATTERN = /\b(only|first|friendly|ok)\b/gi;
+
+function toCountry(segment: string): string | undefined {
+  return COUNTRY_ALIASES[segment.toLowerCase().replace(/\.$/, '')];
+}
+
+/**
+ * Split a scraped location like "San Francisco, CA (Hybrid)" or
+ * "Remote - US" into its parts. Workplace markers (remote/hybrid/on-site)
+ * are pulled out wherever they appear.
+ */
+export function normalizeLocation(raw: string | undefined): NormalizedLocation {
+  const text = (raw ?? '').trim();
+  const hybrid = HYBRID_PATTERN.test(text);
+  const remote = !hybrid && REMOTE_PATTERN.test(text);
+
+  const segments = text
+    .replace(WORKPLACE_PATTERN, ' ')
+    .replace(FILLER_PATTERN, ' ')
+    .split(/\s+[-–—]\s+|[,|/;()]+/)
+    .map((s) => s.replace(/\s+/g, ' ').replace(/^[-–—\s]+|[-–—\s]+$/g, ''))
+    .filter(Boolean);
+
+  const result: NormalizedLocation = { remote, hybrid };
+
+  const last = segments[segments.length - 1];
+  if (last) {
+    const country = toCountry(last);
+    if (country) {
+      result.country = country;
+      segments.pop();
+    }
+  }
+
+  if (segments.length > 0) {
+    const maybeRegion = segments[segments.length - 1];
+    if (US_STATES.has(maybeRegion.toUpperCase()) && maybeRegion.length === 2) {
+      result.region = maybeRegion.toUpperCase();
+      result.country ??= 'United States';
+      segments.pop();
+    } else if (segments.length >= 2) {
+      result.region = segments.pop();
+    }
+  }
+
+  if (segments.length > 0) {
+    result.city = segments.join(', ');
+  }
+
+  return result;
+}
+
+/**
+ * One-line form: "San Francisco, CA, United States (Hybrid)".
+ */
+export function formatLocation(location: NormalizedLocation): string {
+  const place = [location.city, location.region, location.country].filter(Boolean).join(', ');
+  const mode = location.remote ? 'Remote' : location.hybrid ? 'Hybrid' : '';
+  if (!place) return mode;
+  return mode ? `${place} (${mode})` : place;
+}
+
+/**
+ * Whether a job can be done fully remotely, based on its location and
+ * workplace-type text. Hybrid roles are not remote.
+ */
+export function isRemoteJob(job: { location?: string; job_type?: string; remote?: boolean }): boolean {
+  if (job.remote !== undefined) return job.remote;
+  return [job.location, job.job_type].some((text) => normalizeLocation(text).remote);
+}
+
+export interface LocationMatch {
+  ok: boolean;
+  /** The job's location as shown to the user, e.g. "Austin, TX, United States (Hybrid)" */
+  where: string;
+}
+
+/**
+ * Whether a job's location suits the candidate. Uses the parsed remote flag
+ * rather than looking for "remote" in the text, so with remoteOnly a hybrid
+ * role fails. Jobs with no location at all pass: there's nothing to judge.
+ */
+export function matchLocation(
+  job: { location?: string; job_type?: string; remote?: boolean },
+  options: { remoteOnly?: boolean }
+): LocationMatch {
+  const remote = isRemoteJob(job);
+  const place = formatLocation(normalizeLocation(job.location));
+  const where = remote && !place.includes('Remote') ? (place ? `${place} (Remote)` : 'Remote') : place || 'on-site';
+
+  const known = job.location !== undefined || job.remote !== undefined;
+  if (!options.remoteOnly || !known) return { ok: true, where };
+  return { ok: remote, where };
+}
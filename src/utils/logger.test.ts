@@ -1,5 +1,5 @@
-import { describe, test, expect, spyOn, beforeEach } from 'bun:test';
-import { setVerbose, logger } from './logger';
+import { describe, test, expect, spyOn, beforeEach, afterEach } from 'bun:test';
+import { setVerbose, logger, chalk, setColorEnabled, shouldUseColor } from './logger';
 
 describe('logger verbose mode', () => {
   let consoleSpy: ReturnType<typeof spyOn>;
@@ -27,3 +27,45 @@ describe('logger verbose mode', () => {
     expect(consoleSpy).toHaveBeenCalled();
   });
 });
+
+describe('color output', () => {
+  const ANSI = /\u001b\[/;
+  const originalLevel = chalk.level;
+
+  afterEach(() => {
+    chalk.level = originalLevel;
+  });
+
+  test('NO_COLOR wins over everything', () => {
+    expect(shouldUseColor({ NO_COLOR: '1' }, true)).toBe(false);
+    expect(shouldUseColor({ NO_COLOR: '1', FORCE_COLOR: '1' }, true)).toBe(false);
+  });
+
+  test('colors only real terminals unless forced', () => {
+    expect(shouldUseColor({}, true)).toBe(true);
+    expect(shouldUseColor({}, false)).toBe(false);
+    expect(shouldUseColor({ TERM: 'dumb' }, true)).toBe(false);
+    expect(shouldUseColor({ FORCE_COLOR: '1' }, false)).toBe(true);
+  });
+
+  test('disabled color leaves no escape codes in output', () => {
+    const lines: string[] = [];
+    const spy = spyOn(console, 'log').mockImplementation((...args: unknown[]) => {
+      lines.push(args.join(' '));
+    });
+
+    setColorEnabled(false);
+    logger.success('done');
+    logger.keyValue('Status', logger.green('submitted'));
+    logger.header('History');
+    spy.mockRestore();
+
+    expect(lines.join('\n')).toContain('done');
+    expect(lines.join('\n')).not.toMatch(ANSI);
+  });
+
+  test('enabled color styles output', () => {
+    setColorEnabled(true);
+    expect(chalk.red('x')).toMatch(ANSI);
+  });
+});
@@ -11,6 +11,24 @@ export function isVerbose(): boolean {
   return _verbose || !!process.env.DEBUG;
 }
 
+const detectedColorLevel = chalk.level;
+
+/**
+ * Whether to style output: NO_COLOR (any value) turns it off, FORCE_COLOR
+ * turns it on, otherwise only real terminals get colors.
+ */
+export function shouldUseColor(env: NodeJS.ProcessEnv, isTTY: boolean | undefined): boolean {
+  if (env.NO_COLOR !== undefined && env.NO_COLOR !== '') return false;
+  if (env.FORCE_COLOR !== undefined && env.FORCE_COLOR !== '0' && env.FORCE_COLOR !== 'false') return true;
+  if (env.TERM === 'dumb') return false;
+  return Boolean(isTTY);
+}
+
+/** Turn ANSI styling on or off for everything that prints through chalk. */
+export function setColorEnabled(enabled: boolean): void {
+  chalk.level = enabled ? detectedColorLevel || 1 : 0;
+}
+
 export const logger = {
   info: (message: string) => console.log(chalk.blue('ℹ'), message),
   success: (message: string) => console.log(chalk.green('✔'), message),
@@ -0,0 +1,10 @@
+import { describe, expect, test } from 'bun:test';
+import { mentions } from './mentions';
+
+describe('mentions', () => {
+  test('matches whole terms, including punctuation-heavy ones', () => {
+    expect(mentions('Wrote services in Go and C++', 'go')).toBe(true);
+    expect(mentions('Wrote services in Go and C++', 'C++')).toBe(true);
+    expect(mentions('Google Cloud', 'Go')).toBe(false);
+  });
+});
@@ -0,0 +1,9 @@
+function escapeRegExp(value: string): string {
+  return value.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+}
+
+/** Case-insensitive whole-term match that still works for terms like "C++" or ".NET". */
+export function mentions(text: string, term: string): boolean {
+  const pattern = new RegExp(`(^|[^a-z0-9])${escapeRegExp(term.trim().toLowerCase())}(?![a-z0-9])`);
+  return pattern.test(text.toLowerCase());
+}
@@ -0,0 +1,27 @@
+import { describe, expect, test } from 'bun:test';
+import { splitName, preferredFirstName } from './name';
+
+describe('splitName', () => {
+  test.each([
+    ['Ada Lovelace', 'Ada', 'Lovelace'],
+    ['Cher', 'Cher', ''],
+    ['  Ada   Lovelace  ', 'Ada', 'Lovelace'],
+    ['Mary Jane Watson', 'Mary Jane', 'Watson'],
+    ['Ludwig van Beethoven', 'Ludwig', 'van Beethoven'],
+    ['Martin Luther King Jr.', 'Martin Luther', 'King Jr.'],
+    ['Martin Luther King, Jr.', 'Martin Luther', 'King Jr.'],
+    ['John Smith III', 'John', 'Smith III'],
+    ['Lovelace, Ada', 'Ada', 'Lovelace'],
+    ['Van Morrison', 'Van', 'Morrison'],
+    ['', '', ''],
+  ])('%p -> first %p, last %p', (full, first, last) => {
+    expect(splitName(full)).toEqual({ first, last });
+  });
+});
+
+describe('preferredFirstName', () => {
+  test('uses the first given name', () => {
+    expect(preferredFirstName('Mary Jane Watson')).toBe('Mary');
+    expect(preferredFirstName('Lovelace, Ada')).toBe('Ada');
+  });
+});
@@ -0,0 +1,50 @@
+export interface NameParts {
+  first: string;
+  last: string;
+}
+
+const SUFFIXES = new Set(['jr', 'sr', 'ii', 'iii', 'iv', 'v', 'phd', 'md', 'esq']);
+const PARTICLES = new Set(['van', 'von', 'der', 'den', 'de', 'da', 'das', 'do', 'dos', 'del', 'della', 'di', 'du', 'la', 'le', 'bin', 'binti', 'al', 'ibn', 'st.', 'mac']);
+
+function isSuffix(token: string): boolean {
+  return SUFFIXES.has(token.toLowerCase().replace(/[.,]/g, ''));
+}
+
+/**
+ * Split a full name for forms with separate first/last fields. The last word
+ * is the surname, together with any lower-case particles before it ("van
+ * Beethoven") and a trailing suffix ("King Jr."). "Last, First" is honoured
+ * as written, which is also the way to override the guess.
+ */
+export function splitName(fullName: string): NameParts {
+  const name = fullName.replace(/\s+/g, ' ').trim();
+  if (!name) return { first: '', last: '' };
+
+  const comma = name.match(/^([^,]+),\s*(.+)$/);
+  if (comma && !isSuffix(comma[2])) {
+    return { first: comma[2].trim(), last: comma[1].trim() };
+  }
+
+  const tokens = name.replace(/,/g, '').split(' ');
+  if (tokens.length === 1) return { first: tokens[0], last: '' };
+
+  let end = tokens.length;
+  while (end > 2 && isSuffix(tokens[end - 1])) end--;
+
+  let start = end - 1;
+  while (start > 1 && PARTICLES.has(tokens[start - 1]) && tokens[start - 1] === tokens[start - 1].toLowerCase()) {
+    start--;
+  }
+
+  return {
+    first: tokens.slice(0, start).join(' '),
+    last: tokens.slice(start).join(' '),
+  };
+}
+
+/**
+ * Given name for "preferred name" fields: the first word of the first name.
+ */
+export function preferredFirstName(fullName: string): string {
+  return splitName(fullName).first.split(' ')[0] ?? '';
+}
@@ -0,0 +1,52 @@
+import { describe, expect, test } from 'bun:test';
+import { openCommandFor, openUrl } from './open-url';
+
+const URL = 'https://jobs.lever.co/acme/123?lever-source=x&team=eng';
+
+describe('openCommandFor', () => {
+  test('uses open on macOS', () => {
+    expect(openCommandFor(URL, 'darwin')).toEqual({ command: 'open', args: [URL] });
+  });
+
+  test('uses xdg-open on Linux and other Unixes', () => {
+    expect(openCommandFor(URL, 'linux')).toEqual({ command: 'xdg-open', args: [URL] });
+    expect(openCommandFor(URL, 'freebsd').command).toBe('xdg-open');
+  });
+
+  test('uses the URL protocol handler on Windows', () => {
+    expect(openCommandFor(URL, 'win32')).toEqual({
+      command: 'rundll32',
+      args: ['url.dll,FileProtocolHandler', URL],
+    });
+  });
+});
+
+describe('openUrl', () => {
+  test('runs the platform command', async () => {
+    const calls: Array<[string, string[]]> = [];
+    await openUrl(URL, async (command, args) => {
+      calls.push([command, args]);
+    }, 'darwin');
+
+    expect(calls).toEqual([['open', [URL]]]);
+  });
+
+  test('rejects non-web URLs without running anything', async () => {
+    let ran = false;
+    const run = async () => {
+      ran = true;
+    };
+
+    await expect(openUrl('file:///etc/passwd', run, 'linux')).rejects.toThrow('non-web');
+    await expect(openUrl('not a url', run, 'linux')).rejects.toThrow('Not a valid URL');
+    expect(ran).toBe(false);
+  });
+
+  test('reports a missing opener', async () => {
+    const run = async () => {
+      throw new Error('spawn xdg-open ENOENT');
+    };
+
+    await expect(openUrl(URL, run, 'linux')).rejects.toThrow('Could not run xdg-open');
+  });
+});
@@ -0,0 +1,55 @@
+import { spawn } from 'child_process';
+
+export interface OpenCommand {
+  command: string;
+  args: string[];
+}
+
+export type CommandRunner = (command: string, args: string[]) => Promise<void>;
+
+/** The OS command that opens a URL in the default browser. */
+export function openCommandFor(url: string, platform: NodeJS.Platform = process.platform): OpenCommand {
+  switch (platform) {
+    case 'darwin':
+      return { command: 'open', args: [url] };
+    case 'win32':
+      // Avoids cmd's "start", which needs & and quotes escaped
+      return { command: 'rundll32', args: ['url.dll,FileProtocolHandler', url] };
+    default:
+      return { command: 'xdg-open', args: [url] };
+  }
+}
+
+const spawnDetached: CommandRunner = (command, args) =>
+  new Promise((resolve, reject) => {
+    const child = spawn(command, args, { detached: true, stdio: 'ignore' });
+    child.once('error', reject);
+    child.once('spawn', () => {
+      child.unref();
+      resolve();
+    });
+  });
+
+export async function openUrl(
+  url: string,
+  run: CommandRunner = spawnDetached,
+  platform: NodeJS.Platform = process.platform
+): Promise<void> {
+  let parsed: URL;
+  try {
+    parsed = new URL(url);
+  } catch {
+    throw new Error(`Not a valid URL: ${url}`);
+  }
+  if (parsed.protocol !== 'http:' && parsed.protocol !== 'https:') {
+    throw new Error(`Refusing to open non-web URL: ${url}`);
+  }
+
+  const { command, args } = openCommandFor(parsed.toString(), platform);
+  try {
+    await run(command, args);
+  } catch (error) {
+    const msg = error instanceof Error ? error.message : String(error);
+    throw new Error(`Could not run ${command}: ${msg}`);
+  }
+}
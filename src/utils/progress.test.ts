@@ -0,0 +1,26 @@
+import { describe, expect, test } from 'bun:test';
+import { formatProgress, progressState } from './progress';
+
+describe('progressState', () => {
+  test('computes whole percentages', () => {
+    expect(progressState(0, 4)).toEqual({ current: 0, total: 4, percent: 0 });
+    expect(progressState(1, 3)).toEqual({ current: 1, total: 3, percent: 33 });
+    expect(progressState(4, 4)).toEqual({ current: 4, total: 4, percent: 100 });
+  });
+
+  test('clamps out-of-range values', () => {
+    expect(progressState(7, 4).current).toBe(4);
+    expect(progressState(-1, 4).percent).toBe(0);
+  });
+
+  test('an empty batch is complete', () => {
+    expect(progressState(0, 0).percent).toBe(100);
+  });
+});
+
+describe('formatProgress', () => {
+  test('draws a bar proportional to the percentage', () => {
+    expect(formatProgress(progressState(3, 10))).toBe('[######--------------] 3/10 30%');
+    expect(formatProgress(progressState(2, 2), 4)).toBe('[####] 2/2 100%');
+  });
+});
@@ -0,0 +1,19 @@
+export interface ProgressState {
+  current: number;
+  total: number;
+  /** Whole percent, 0-100 */
+  percent: number;
+}
+
+export function progressState(current: number, total: number): ProgressState {
+  const clamped = Math.max(0, Math.min(current, total));
+  const percent = total > 0 ? Math.floor((clamped / total) * 100) : 100;
+  return { current: clamped, total, percent };
+}
+
+/** "[######--------------] 3/10 30%" */
+export function formatProgress(state: ProgressState, width = 20): string {
+  const filled = Math.round((state.percent / 100) * width);
+  const bar = '#'.repeat(filled) + '-'.repeat(width - filled);
+  return `[${bar}] ${state.current}/${state.total} ${state.percent}%`;
+}
@@ -0,0 +1,84 @@
+import { describe, expect, test, afterEach } from 'bun:test';
+import type { Server } from 'bun';
+import { isAllowedByRobots, RobotsChecker } from './robots';
+
+const ROBOTS = `
+# Example
+User-agent: *
+Disallow: /private/
+Disallow: /jobs/*/apply$
+Allow: /private/jobs/
+
+User-agent: BadBot
+Disallow: /
+`;
+
+describe('isAllowedByRobots', () => {
+  test('allows paths no rule covers', () => {
+    expect(isAllowedByRobots(ROBOTS, '/careers/123')).toBe(true);
+  });
+
+  test('disallows matching prefixes', () => {
+    expect(isAllowedByRobots(ROBOTS, '/private/admin')).toBe(false);
+  });
+
+  test('lets the longest matching rule win', () => {
+    expect(isAllowedByRobots(ROBOTS, '/private/jobs/42')).toBe(true);
+  });
+
+  test('supports * and $ wildcards', () => {
+    expect(isAllowedByRobots(ROBOTS, '/jobs/42/apply')).toBe(false);
+    expect(isAllowedByRobots(ROBOTS, '/jobs/42/apply?step=2')).toBe(true);
+  });
+
+  test('uses the most specific user-agent group', () => {
+    expect(isAllowedByRobots(ROBOTS, '/careers', 'BadBot/1.0')).toBe(false);
+  });
+
+  test('treats an empty Disallow as allow-all', () => {
+    expect(isAllowedByRobots('User-agent: *\nDisallow:', '/anything')).toBe(true);
+    expect(isAllowedByRobots('User-agent: *\nDisallow:\n\nUser-agent: BadBot\nDisallow: /', '/anything')).toBe(true);
+  });
+});
+
+describe('RobotsChecker', () => {
+  let server: Server | undefined;
+
+  afterEach(() => {
+    server?.stop(true);
+    server = undefined;
+  });
+
+  function serve(robots: string | null): { origin: string; hits: () => number } {
+    let hits = 0;
+    server = Bun.serve({
+      port: 0,
+      fetch(req) {
+        if (new URL(req.url).pathname === '/robots.txt') {
+          hits++;
+          return robots === null ? new Response('not found', { status: 404 }) : new Response(robots);
+        }
+        return new Response('ok');
+      },
+    });
+    return { origin: `http://localhost:${server.port}`, hits: () => hits };
+  }
+
+  test('blocks a disallowed path and caches robots.txt per host', async () => {
+    const { origin, hits } = serve('User-agent: *\nDisallow: /jobs/secret');
+    const checker = new RobotsChecker();
+
+    expect(await checker.isAllowed(`${origin}/jobs/secret`)).toBe(false);
+    expect(await checker.isAllowed(`${origin}/jobs/open`)).toBe(true);
+    expect(hits()).toBe(1);
+  });
+
+  test('allows everything when robots.txt is missing or unreachable', async () => {
+    const { origin } = serve(null);
+
+    expect(await new RobotsChecker().isAllowed(`${origin}/jobs/1`)).toBe(true);
+    expect(
+      await new RobotsChecker(() => Promise.reject(new Error('ECONNREFUSED'))).isAllowed('https://example.com/x')
+    ).toBe(true);
+  });
+});
@@ -0,0 +1,125 @@
+/**
+ * Minimal robots.txt support: user-agent groups with Allow/Disallow rules,
+ * longest match wins, "*" and "$" wildcards.
+ */
+
+export const ROBOTS_USER_AGENT = 'autoply';
+
+interface RobotsRule {
+  allow: boolean;
+  path: string;
+}
+
+interface RobotsGroup {
+  agents: string[];
+  rules: RobotsRule[];
+}
+
+export class RobotsDisallowedError extends Error {
+  constructor(url: string) {
+    super(`${url} is disallowed by robots.txt (pass --ignore-robots to scrape it anyway)`);
+    this.name = 'RobotsDisallowedError';
+  }
+}
+
+export function parseRobotsTxt(text: string): RobotsGroup[] {
+  const groups: RobotsGroup[] = [];
+  let current: RobotsGroup | null = null;
+  let lastWasAgent = false;
+
+  for (const rawLine of text.split(/\r?\n/)) {
+    const line = rawLine.replace(/#.*$/, '').trim();
+    const colon = line.indexOf(':');
+    if (colon < 0) continue;
+
+    const field = line.slice(0, colon).trim().toLowerCase();
+    const value = line.slice(colon + 1).trim();
+
+    if (field === 'user-agent') {
+      // Consecutive user-agent lines share one group
+      if (!current || !lastWasAgent) {
+        current = { agents: [], rules: [] };
+        groups.push(current);
+      }
+      current.agents.push(value.toLowerCase());
+      lastWasAgent = true;
+    } else if ((field === 'allow' || field === 'disallow') && current) {
+      // An empty Disallow means "allow everything"
+      if (value) current.rules.push({ allow: field === 'allow', path: value });
+      lastWasAgent = false;
+    }
+  }
+
+  return groups;
+}
+
+function ruleMatches(rulePath: string, path: string): boolean {
+  const anchored = rulePath.endsWith('$');
+  const pattern = (anchored ? rulePath.slice(0, -1) : rulePath)
+    .split('*')
+    .map((part) => part.replace(/[.+?^${}()|[\]\\]/g, '\\$&'))
+    .join('.*');
+  return new RegExp(`^${pattern}${anchored ? '$' : ''}`).test(path);
+}
+
+/** Whether robots.txt lets the agent fetch a path (including any query string). */
+export function isAllowedByRobots(robotsTxt: string, path: string, agent = ROBOTS_USER_AGENT): boolean {
+  const groups = parseRobotsTxt(robotsTxt);
+  const name = agent.toLowerCase();
+  const group =
+    groups.find((g) => g.agents.some((a) => a !== '*' && name.includes(a))) ??
+    groups.find((g) => g.agents.includes('*'));
+  if (!group) return true;
+
+  let best: RobotsRule | null = null;
+  for (const rule of group.rules) {
+    if (!ruleMatches(rule.path, path)) continue;
+    // Longest path wins; on a tie Allow wins
+    if (!best || rule.path.length > best.path.length || (rule.path.length === best.path.length && rule.allow)) {
+      best = rule;
+    }
+  }
+  return best?.allow ?? true;
+}
+
+export type RobotsFetch = (url: string) => Promise<Response>;
+
+/**
+ * Fetches robots.txt once per origin. A missing or unreachable robots.txt
+ * allows everything.
+ */
+export class RobotsChecker {
+  private cache = new Map<string, Promise<string | null>>();
+
+  constructor(private fetchFn: RobotsFetch = (url) => fetch(url, { signal: AbortSignal.timeout(10_000) })) {}
+
+  async isAllowed(url: string): Promise<boolean> {
+    const target = new URL(url);
+    const robotsTxt = await this.load(target.origin);
+    return robotsTxt === null || isAllowedByRobots(robotsTxt, target.pathname + target.search);
+  }
+
+  private load(origin: string): Promise<string | null> {
+    let pending = this.cache.get(origin);
+    if (!pending) {
+      pending = this.fetchFn(`${origin}/robots.txt`)
+        .then((response) => (response.ok ? response.text() : null))
+        .catch(() => null);
+      this.cache.set(origin, pending);
+    }
+    return pending;
+  }
+}
+
+let ignoreRobots = false;
+
+/** Set by the global --ignore-robots flag. */
+export function setIgnoreRobots(ignore: boolean): void {
+  ignoreRobots = ignore;
+}
+
+export function isIgnoringRobots(): boolean {
+  return ignoreRobots;
+}
+
+export const robotsChecker = new RobotsChecker();
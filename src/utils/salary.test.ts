@@ -0,0 +1,60 @@
+import { describe, expect, test } from 'bun:test';
+import { formatSalaryRange, parseSalaryRange, salaryDecision } from './salary';
+
+describe('parseSalaryRange', () => {
+  test('parses comma-grouped ranges', () => {
+    expect(parseSalaryRange('$120,000 - $150,000')).toEqual({ min: 120000, max: 150000, period: 'year' });
+    expect(parseSalaryRange('USD 100,000 to 130,000 per year')).toEqual({ min: 100000, max: 130000, period: 'year' });
+  });
+
+  test('parses k suffixes, including one shared across the range', () => {
+    expect(parseSalaryRange('$120k–150k')).toEqual({ min: 120000, max: 150000, period: 'year' });
+    expect(parseSalaryRange('£90-110K')).toEqual({ min: 90000, max: 110000, period: 'year' });
+  });
+
+  test('parses European thousands separators', () => {
+    expect(parseSalaryRange('€50.000 - €60.000')).toEqual({ min: 50000, max: 60000, period: 'year' });
+  });
+
+  test('annualizes hourly and monthly pay', () => {
+    expect(parseSalaryRange('$45/hr')).toEqual({ min: 93600, max: 93600, period: 'hour' });
+    expect(parseSalaryRange('$22.50 - $30 per hour')).toEqual({ min: 46800, max: 62400, period: 'hour' });
+    expect(parseSalaryRange('5,000 per month')).toEqual({ min: 60000, max: 60000, period: 'month' });
+  });
+
+  test('handles open-ended amounts', () => {
+    expect(parseSalaryRange('Up to $90k')).toEqual({ max: 90000, period: 'year' });
+    expect(parseSalaryRange('$100,000+')).toEqual({ min: 100000, period: 'year' });
+  });
+
+  test('returns null when there is no amount', () => {
+    expect(parseSalaryRange('Competitive')).toBeNull();
+    expect(parseSalaryRange(undefined)).toBeNull();
+  });
+});
+
+describe('salaryDecision', () => {
+  test('below when the top of the range is under the minimum', () => {
+    expect(salaryDecision('$80k - $95k', 100000)).toBe('below');
+    expect(salaryDecision('Up to $90k', 100000)).toBe('below');
+  });
+
+  test('ok when the range reaches the minimum', () => {
+    expect(salaryDecision('$90k - $110k', 100000)).toBe('ok');
+    expect(salaryDecision('$100,000', 100000)).toBe('ok');
+    expect(salaryDecision('From $80k', 100000)).toBe('ok');
+  });
+
+  test('unknown when the salary cannot be parsed', () => {
+    expect(salaryDecision('Competitive + equity', 100000)).toBe('unknown');
+    expect(salaryDecision(undefined, 100000)).toBe('unknown');
+  });
+});
+
+describe('formatSalaryRange', () => {
+  test('formats ranges in thousands per year', () => {
+    expect(formatSalaryRange({ min: 120000, max: 150000, period: 'year' })).toBe('120k-150k/yr');
+    expect(formatSalaryRange({ max: 90000, period: 'year' })).toBe('up to 90k/yr');
+    expect(formatSalaryRange({ min: 100000, period: 'year' })).toBe('100k+/yr');
+  });
+});
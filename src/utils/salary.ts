@@ -0,0 +1,88 @@
+export type SalaryPeriod = 'year' | 'month' | 'hour';
+
+export interface SalaryRange {
+  /** Annualized lower bound, when the posting gives one */
+  min?: number;
+  /** Annualized upper bound, when the posting gives one */
+  max?: number;
+  period: SalaryPeriod;
+}
+
+export type SalaryDecision = 'ok' | 'below' | 'unknown';
+
+const HOURS_PER_YEAR = 2080;
+const AMOUNT_PATTERN = /(\d{1,3}(?:[,.\s]\d{3})+|\d+(?:\.\d+)?)\s*(k\b)?/gi;
+
+function detectPeriod(text: string): SalaryPeriod {
+  if (/per\s+hour|hourly|an\s+hour|\/\s*h(?:ou)?r\b/.test(text)) return 'hour';
+  if (/per\s+month|monthly|a\s+month|\/\s*mo(?:nth)?\b/.test(text)) return 'month';
+  return 'year';
+}
+
+function annualize(amount: number, period: SalaryPeriod): number {
+  if (period === 'hour') return Math.round(amount * HOURS_PER_YEAR);
+  if (period === 'month') return amount * 12;
+  return amount;
+}
+
+/**
+ * Parse a posted salary such as "$120k - $150k", "USD 100,000 to 130,000",
+ * "£45/hr" or "Up to 90K" into an annualized range. Currencies are not
+ * converted. Returns null when no amount can be found.
+ */
+export function parseSalaryRange(text: string | undefined): SalaryRange | null {
+  if (!text) return null;
+  const lower = text.toLowerCase();
+
+  const amounts: Array<{ value: number; thousands: boolean }> = [];
+  for (const match of lower.matchAll(AMOUNT_PATTERN)) {
+    const digits = match[1];
+    const grouped = /[,.\s]\d{3}$/.test(digits) && !/^\d+\.\d{1,2}$/.test(digits);
+    const value = grouped ? Number(digits.replace(/[,.\s]/g, '')) : Number(digits);
+    if (!Number.isFinite(value) || value === 0) continue;
+    amounts.push({ value, thousands: Boolean(match[2]) });
+    if (amounts.length === 2) break;
+  }
+  if (amounts.length === 0) return null;
+
+  // "120-150k": the suffix on the second amount applies to both
+  if (amounts.length === 2 && amounts[1].thousands && !amounts[0].thousands && amounts[0].value < 1000) {
+    amounts[0].thousands = true;
+  }
+  const values = amounts.map((a) => (a.thousands ? a.value * 1000 : a.value));
+
+  const period = detectPeriod(lower);
+  const [low, high] = values.length === 2 ? [Math.min(...values), Math.max(...values)] : [values[0], values[0]];
+
+  if (values.length === 1 && /up\s+to|max(?:imum)?/.test(lower)) {
+    return { max: annualize(high, period), period };
+  }
+  if (values.length === 1 && /from|starting|min(?:imum)?|\+/.test(lower)) {
+    return { min: annualize(low, period), period };
+  }
+  return { min: annualize(low, period), max: annualize(high, period), period };
+}
+
+/**
+ * Compare a posting's salary against the candidate's minimum. Jobs are only
+ * "below" when the top of the posted range is under the minimum; postings
+ * that don't state a salary are "unknown" rather than rejected.
+ */
+export function salaryDecision(salary: string | undefined, minSalary: number): SalaryDecision {
+  const range = parseSalaryRange(salary);
+  if (!range) return 'unknown';
+  const top = range.max ?? range.min;
+  if (top === undefined) return 'unknown';
+  // "From 80k" has no ceiling, so it can still meet any minimum
+  if (range.max === undefined) return 'ok';
+  return top < minSalary ? 'below' : 'ok';
+}
+
+export function formatSalaryRange(range: SalaryRange): string {
+  const fmt = (n: number) => `${Math.round(n / 1000)}k`;
+  if (range.min !== undefined && range.max !== undefined) {
+    return range.min === range.max ? `${fmt(range.min)}/yr` : `${fmt(range.min)}-${fmt(range.max)}/yr`;
+  }
+  if (range.max !== undefined) return `up to ${fmt(range.max)}/yr`;
+  return `${fmt(range.min!)}+/yr`;
+}
@@ -0,0 +1,48 @@
+import { describe, expect, test } from 'bun:test';
+import { Semaphore } from './semaphore';
+
+/** Resolves true if the promise settles within a tick, false if it's still pending. */
+async function settled(promise: Promise<unknown>): Promise<boolean> {
+  let done = false;
+  promise.then(() => (done = true));
+  await Bun.sleep(5);
+  return done;
+}
+
+describe('Semaphore', () => {
+  test('blocks the N+1th acquire until a slot is released', async () => {
+    const semaphore = new Semaphore(2);
+    const first = await semaphore.acquire();
+    await semaphore.acquire();
+
+    const third = semaphore.acquire();
+    expect(await settled(third)).toBe(false);
+    expect(semaphore.inUse).toBe(2);
+
+    first();
+    expect(await settled(third)).toBe(true);
+    expect(semaphore.inUse).toBe(2);
+  });
+
+  test('serves waiters in order and ignores double releases', async () => {
+    const semaphore = new Semaphore(1);
+    const release = await semaphore.acquire();
+    const order: string[] = [];
+    const a = semaphore.acquire().then((r) => (order.push('a'), r));
+    const b = semaphore.acquire().then((r) => (order.push('b'), r));
+
+    release();
+    release();
+    const releaseA = await a;
+    expect(await settled(b)).toBe(false);
+
+    releaseA();
+    (await b)();
+    expect(order).toEqual(['a', 'b']);
+    expect(semaphore.inUse).toBe(0);
+  });
+
+  test('rejects a non-positive limit', () => {
+    expect(() => new Semaphore(0)).toThrow('positive integer');
+  });
+});
@@ -0,0 +1,37 @@
+/**
+ * Counting semaphore for capping concurrent work (e.g. open browsers).
+ * acquire() resolves with a release function once a slot is free; waiters
+ * are served in order. Releasing twice is a no-op.
+ */
+export class Semaphore {
+  private active = 0;
+  private waiting: Array<() => void> = [];
+
+  constructor(private limit: number) {
+    if (!Number.isInteger(limit) || limit < 1) {
+      throw new Error(`Semaphore limit must be a positive integer, got ${limit}`);
+    }
+  }
+
+  get inUse(): number {
+    return this.active;
+  }
+
+  async acquire(): Promise<() => void> {
+    if (this.active < this.limit) {
+      this.active++;
+    } else {
+      // The releaser hands its slot straight to us, so active stays the same
+      await new Promise<void>((resolve) => this.waiting.push(resolve));
+    }
+
+    let released = false;
+    return () => {
+      if (released) return;
+      released = true;
+      const next = this.waiting.shift();
+      if (next) next();
+      else this.active--;
+    };
+  }
+}
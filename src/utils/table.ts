@@ -0,0 +1,21 @@
+/**
+ * Align rows into space-separated columns. Cells are plain text; colour them
+ * after layout if needed so ANSI codes don't throw off the widths.
+ */
+export function formatTable(headers: string[], rows: string[][], gap = 2): string[] {
+  const widths = headers.map((header, col) =>
+    Math.max(header.length, ...rows.map((row) => (row[col] ?? '').length))
+  );
+
+  const render = (cells: string[]) =>
+    cells
+      .map((cell, col) => (col === cells.length - 1 ? cell : cell.padEnd(widths[col] + gap)))
+      .join('')
+      .trimEnd();
+
+  return [render(headers), ...rows.map(render)];
+}
+
+export function truncate(text: string, max: number): string {
+  return text.length > max ? `${text.slice(0, max - 1)}…` : text;
+}
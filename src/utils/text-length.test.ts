@@ -0,0 +1,18 @@
+import { describe, expect, test } from 'bun:test';
+import { countText, formatTextLength } from './text-length';
+
+describe('countText', () => {
+  test('counts words and trimmed characters', () => {
+    expect(countText('  Dear Acme,\n\nI build   APIs.  ')).toEqual({ words: 5, chars: 27 });
+  });
+
+  test('treats blank text as empty', () => {
+    expect(countText(' \n ')).toEqual({ words: 0, chars: 0 });
+  });
+});
+
+describe('formatTextLength', () => {
+  test('reads naturally', () => {
+    expect(formatTextLength({ words: 3, chars: 18 })).toBe('3 words, 18 characters');
+  });
+});
@@ -0,0 +1,17 @@
+export interface TextLength {
+  words: number;
+  chars: number;
+}
+
+/** Word and character counts the way application forms count them (whitespace trimmed). */
+export function countText(text: string): TextLength {
+  const trimmed = text.trim();
+  return {
+    words: trimmed ? trimmed.split(/\s+/).length : 0,
+    chars: trimmed.length,
+  };
+}
+
+export function formatTextLength(length: TextLength): string {
+  return `${length.words} words, ${length.chars} characters`;
+}
@@ -1,5 +1,5 @@
 import { describe, test, expect } from 'bun:test';
-import { normalizeUrl } from './url-parser';
+import { normalizeUrl, parseUrlList, detectUrlFileFormat } from './url-parser';
 
 describe('normalizeUrl', () => {
   test('strips trailing slash', () => {
@@ -40,3 +40,39 @@ describe('normalizeUrl', () => {
     expect(normalizeUrl('https://example.com')).toBe('https://example.com/');
   });
 });
+
+describe('parseUrlList', () => {
+  test('reads plain lists and skips comments', () => {
+    expect(parseUrlList('# saved jobs\nhttps://a.com/1\n\n  https://a.com/2  \n', 'text')).toEqual([
+      'https://a.com/1',
+      'https://a.com/2',
+    ]);
+  });
+
+  test('reads the url column from CSV and skips rows without one', () => {
+    const csv = 'Company,Title,URL\nAcme,Engineer,https://a.com/1\nGlobex,"Designer, Senior",\nInitech,PM,https://a.com/3\n';
+    expect(parseUrlList(csv, 'csv')).toEqual(['https://a.com/1', 'https://a.com/3']);
+  });
+
+  test('rejects CSV without a url column', () => {
+    expect(() => parseUrlList('company,title\nAcme,Engineer\n', 'csv')).toThrow('url column');
+  });
+
+  test('reads JSON arrays of strings or objects', () => {
+    const json = JSON.stringify(['https://a.com/1', { url: 'https://a.com/2', company: 'Acme' }, { title: 'no url' }]);
+    expect(parseUrlList(json, 'json')).toEqual(['https://a.com/1', 'https://a.com/2']);
+  });
+
+  test('rejects JSON that is not an array', () => {
+    expect(() => parseUrlList('{"url": "https://a.com/1"}', 'json')).toThrow('array');
+    expect(() => parseUrlList('not json', 'json')).toThrow('Invalid JSON');
+  });
+});
+
+describe('detectUrlFileFormat', () => {
+  test('uses the file extension', () => {
+    expect(detectUrlFileFormat('jobs.CSV')).toBe('csv');
+    expect(detectUrlFileFormat('jobs.json')).toBe('json');
+    expect(detectUrlFileFormat('jobs.txt')).toBe('text');
+  });
+});
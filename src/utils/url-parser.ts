@@ -1,4 +1,5 @@
 import { SUPPORTED_PLATFORMS, type Platform } from '../types';
+import { parseCsvRecords } from './csv';
 
 export interface ParsedUrl {
   url: string;
@@ -74,15 +75,73 @@ export function getPlatformExamples(): Record<Platform, string> {
   };
 }
 
-export async function readUrlsFromFile(filePath: string): Promise<string[]> {
-  const file = Bun.file(filePath);
-  const content = await file.text();
+export type UrlFileFormat = 'text' | 'csv' | 'json';
+
+const URL_COLUMNS = ['url', 'link', 'job_url', 'job url', 'apply_url'];
+
+export function detectUrlFileFormat(filePath: string): UrlFileFormat {
+  const lower = filePath.toLowerCase();
+  if (lower.endsWith('.csv')) return 'csv';
+  if (lower.endsWith('.json')) return 'json';
+  return 'text';
+}
+
+function pickUrl(record: Record<string, unknown>): string | undefined {
+  for (const column of URL_COLUMNS) {
+    const value = record[column];
+    if (typeof value === 'string' && value.trim()) return value.trim();
+  }
+  return undefined;
+}
+
+/**
+ * Pull job URLs out of a plain list (one per line, # comments), a CSV with a
+ * url column, or a JSON array of URLs / objects with a url field.
+ */
+export function parseUrlList(content: string, format: UrlFileFormat): string[] {
+  if (format === 'csv') {
+    const records = parseCsvRecords(content);
+    if (records.length > 0 && !URL_COLUMNS.some((column) => column in records[0])) {
+      throw new Error(`CSV needs a url column (one of: ${URL_COLUMNS.join(', ')})`);
+    }
+    return records.map(pickUrl).filter((url): url is string => url !== undefined);
+  }
+
+  if (format === 'json') {
+    let parsed: unknown;
+    try {
+      parsed = JSON.parse(content);
+    } catch (error) {
+      const msg = error instanceof Error ? error.message : 'Unknown error';
+      throw new Error(`Invalid JSON: ${msg}`);
+    }
+    if (!Array.isArray(parsed)) {
+      throw new Error('JSON file must contain an array of URLs or objects with a "url" field');
+    }
+    return parsed
+      .map((item) => {
+        if (typeof item === 'string') return item.trim() || undefined;
+        if (item && typeof item === 'object') return pickUrl(item as Record<string, unknown>);
+        return undefined;
+      })
+      .filter((url): url is string => url !== undefined);
+  }
+
   return content
     .split('\n')
     .map((line) => line.trim())
     .filter((line) => line && !line.startsWith('#'));
 }
 
+export async function readUrlsFromFile(
+  filePath: string,
+  format: UrlFileFormat = detectUrlFileFormat(filePath)
+): Promise<string[]> {
+  const file = Bun.file(filePath);
+  const content = await file.text();
+  return parseUrlList(content, format);
+}
+
 export function normalizeUrl(url: string): string {
   try {
     const parsed = new URL(url);